@@ -2,11 +2,14 @@ package router
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -15,24 +18,46 @@ import (
 
 type Request interface {
 	ArgExists(name string) bool
+	Bind(dst interface{}) error
+	BindAndValidate(dst interface{}) error
 	Body() []byte
 	BodyError() error
+	Context() context.Context
+	Get(key string) interface{}
 	GetArg(name string) string
+	GetArgBool(name string) (bool, error)
+	GetArgFloat64(name string) (float64, error)
+	GetArgInt(name string) (int, error)
+	GetArgInt64(name string) (int64, error)
+	GetArgTime(name, layout string) (time.Time, error)
+	GetArgUUID(name string) (string, error)
 	GetHeader(header string) string
 	GetHeaders() map[string][]string
 	GetHost() string
 	GetIP() string
 	GetPostVariable(name string) string
+	GetQuery(name string) string
+	GetQueryAll(name string) []string
+	GetQueryBool(name string) (bool, error)
+	GetQueryFloat64(name string) (float64, error)
+	GetQueryInt(name string) (int, error)
+	GetQueryInt64(name string) (int64, error)
 	GetReferer() string
 	GetURL() string
+	GetRelativeURL() string
 	GetUserAgent() string
 	HasBody() bool
 	HeaderExists(header string) bool
 	PostVariableExists(name string) bool
+	QueryExists(name string) bool
 	Error(response ...interface{}) Response
+	InvalidParam(err error) Response
 	PermanentRedirect(destination string) Response
+	Problem(problem Problem) Response
 	Redirect(destination string) Response
 	Response(response ...interface{}) Response
+	Set(key string, val interface{})
+	SetContext(ctx context.Context)
 	SetHeader(key, val string)
 	Success(response ...interface{}) Response
 	GetResponseStatusCode() int
@@ -46,17 +71,26 @@ func CreateRequest(method, path string, body []byte, params map[string]string) R
 }
 
 func createRequest(method, path string, body []byte, params map[string]string) Request {
-	return &request{input: httptest.NewRequest(method, path, bytes.NewReader(body)), args: params, URL: path}
+	input := httptest.NewRequest(method, path, bytes.NewReader(body))
+	return &request{input: input, args: params, URL: input.URL.Path, RelativeURL: input.URL.Path}
 }
 
 func createRequestAdvanced(req *http.Request, params map[string]string) Request {
-	return &request{input: req, args: params, Host: req.Host, URL: req.URL.Path, UserAgent: req.Header.Get("User-Agent")}
+	return &request{input: req, args: params, Host: req.Host, URL: req.URL.Path, RelativeURL: req.URL.Path, UserAgent: req.Header.Get("User-Agent")}
 }
 
 type request struct {
 	input                *http.Request
+	writer               http.ResponseWriter
+	written              bool
+	problems             bool
+	log                  Log
 	args                 map[string]string
+	values               map[string]interface{}
+	query                url.Values
+	forms                []*multipart.Form
 	Host, URL, UserAgent string
+	RelativeURL          string
 	body                 struct {
 		content   []byte
 		error     error
@@ -93,6 +127,51 @@ func (r *request) GetArg(name string) string {
 	return arg
 }
 
+func (r *request) Context() context.Context {
+	return r.input.Context()
+}
+
+// SetContext replaces the context seen by the request, so that middleware can
+// attach deadlines or values for the handlers further down the chain.
+func (r *request) SetContext(ctx context.Context) {
+	r.input = r.input.WithContext(ctx)
+}
+
+func (r *request) Set(key string, val interface{}) {
+	if r.values == nil {
+		r.values = make(map[string]interface{})
+	}
+	r.values[key] = val
+}
+
+func (r *request) Get(key string) interface{} {
+	return r.values[key]
+}
+
+func (r *request) GetArgInt(name string) (int, error) {
+	return parseInt("path", name, r.GetArg(name), r.ArgExists(name))
+}
+
+func (r *request) GetArgInt64(name string) (int64, error) {
+	return parseInt64("path", name, r.GetArg(name), r.ArgExists(name))
+}
+
+func (r *request) GetArgFloat64(name string) (float64, error) {
+	return parseFloat64("path", name, r.GetArg(name), r.ArgExists(name))
+}
+
+func (r *request) GetArgBool(name string) (bool, error) {
+	return parseBool("path", name, r.GetArg(name), r.ArgExists(name))
+}
+
+func (r *request) GetArgUUID(name string) (string, error) {
+	return parseUUID("path", name, r.GetArg(name), r.ArgExists(name))
+}
+
+func (r *request) GetArgTime(name, layout string) (time.Time, error) {
+	return parseTime("path", name, r.GetArg(name), r.ArgExists(name), layout)
+}
+
 func (r *request) HeaderExists(header string) bool {
 	return len(r.input.Header.Get(header)) > 0
 }
@@ -118,10 +197,52 @@ func (r *request) PostVariableExists(name string) bool {
 	return len(r.GetPostVariable(name)) >= 1
 }
 
+func (r *request) GetQuery(name string) string {
+	return r.queryValues().Get(name)
+}
+
+func (r *request) GetQueryAll(name string) []string {
+	return r.queryValues()[name]
+}
+
+func (r *request) QueryExists(name string) bool {
+	_, exists := r.queryValues()[name]
+	return exists
+}
+
+func (r *request) GetQueryInt(name string) (int, error) {
+	return parseInt("query", name, r.GetQuery(name), r.QueryExists(name))
+}
+
+func (r *request) GetQueryInt64(name string) (int64, error) {
+	return parseInt64("query", name, r.GetQuery(name), r.QueryExists(name))
+}
+
+func (r *request) GetQueryFloat64(name string) (float64, error) {
+	return parseFloat64("query", name, r.GetQuery(name), r.QueryExists(name))
+}
+
+func (r *request) GetQueryBool(name string) (bool, error) {
+	return parseBool("query", name, r.GetQuery(name), r.QueryExists(name))
+}
+
+func (r *request) queryValues() url.Values {
+	if r.query == nil {
+		r.query = r.input.URL.Query()
+	}
+	return r.query
+}
+
 func (r *request) GetURL() string {
 	return r.input.URL.Path
 }
 
+// GetRelativeURL returns the path with the router root removed, or an empty
+// string if the path is outside of every root.
+func (r *request) GetRelativeURL() string {
+	return r.RelativeURL
+}
+
 func (r *request) GetIP() string {
 	forwardedIPs := strings.Split(r.GetHeader("X-Forwarded-For"), ",")
 
@@ -2,10 +2,13 @@ package router
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"net/http/httptest"
 	"net/url"
+	"time"
 )
 
 var _ = Describe("Router unit tests", func() {
@@ -69,6 +72,173 @@ var _ = Describe("Router unit tests", func() {
 		})
 	})
 
+	Context("Typed URL parameters", func() {
+		When("a URL parameter holds a valid value", func() {
+			It("should be converted by the typed methods", func() {
+				req := createRequest("GET", "/", nil, map[string]string{
+					"id":      "12",
+					"big":     "9000000000",
+					"price":   "9.99",
+					"enabled": "true",
+					"uuid":    "3F2504E0-4F89-11D3-9A0C-0305E82C3301",
+					"date":    "2021-03-04",
+				})
+
+				id, err := req.GetArgInt("id")
+				Expect(err).To(BeNil())
+				Expect(id).To(Equal(12))
+
+				big, err := req.GetArgInt64("big")
+				Expect(err).To(BeNil())
+				Expect(big).To(Equal(int64(9000000000)))
+
+				price, err := req.GetArgFloat64("price")
+				Expect(err).To(BeNil())
+				Expect(price).To(Equal(9.99))
+
+				enabled, err := req.GetArgBool("enabled")
+				Expect(err).To(BeNil())
+				Expect(enabled).To(BeTrue())
+
+				uuid, err := req.GetArgUUID("uuid")
+				Expect(err).To(BeNil())
+				Expect(uuid).To(Equal("3f2504e0-4f89-11d3-9a0c-0305e82c3301"))
+
+				date, err := req.GetArgTime("date", "2006-01-02")
+				Expect(err).To(BeNil())
+				Expect(date).To(Equal(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)))
+			})
+		})
+
+		When("a URL parameter holds an invalid value", func() {
+			It("should return a ParamError", func() {
+				req := createRequest("GET", "/", nil, map[string]string{"id": "abc", "uuid": "not-a-uuid"})
+
+				_, err := req.GetArgInt("id")
+				Expect(err).To(MatchError(`path parameter "id" must be a valid int, got "abc"`))
+
+				_, err = req.GetArgUUID("uuid")
+				Expect(err).To(MatchError(`path parameter "uuid" must be a valid uuid, got "not-a-uuid"`))
+			})
+
+			It("should distinguish a missing parameter from an invalid one", func() {
+				req := createRequest("GET", "/", nil, map[string]string{"id": ""})
+
+				_, err := req.GetArgInt("id")
+				Expect(errors.Is(err, ErrParamMissing)).To(BeTrue())
+				Expect(err).To(MatchError(`path parameter "id" is missing`))
+			})
+		})
+
+		When("the InvalidParam method is called", func() {
+			It("should respond with a 400 describing the parameter", func() {
+				req := createRequest("GET", "/", nil, map[string]string{"id": "abc"})
+				_, err := req.GetArgInt("id")
+
+				response := req.InvalidParam(err)
+				Expect(response.GetResponseStatusCode()).To(Equal(400))
+				Expect(response.GetResponseHeaders()["Content-Type"]).To(Equal("application/json"))
+				Expect(string(response.GetResponseContent())).To(MatchJSON(`{"error": "path parameter \"id\" must be a valid int, got \"abc\"", "source": "path", "param": "id"}`))
+			})
+		})
+	})
+
+	Context("Query string parameters", func() {
+		When("a query parameter is present", func() {
+			It("should be available via the GetQuery and QueryExists methods", func() {
+				req := createRequest("GET", "/search?term=router&page=2", nil, nil)
+
+				Expect(req.QueryExists("term")).To(BeTrue())
+				Expect(req.GetQuery("term")).To(Equal("router"))
+				Expect(req.GetURL()).To(Equal("/search"))
+			})
+
+			It("should return every value via the GetQueryAll method", func() {
+				req := createRequest("GET", "/search?tag=a&tag=b", nil, nil)
+				Expect(req.GetQueryAll("tag")).To(Equal([]string{"a", "b"}))
+			})
+
+			It("should convert values via the typed methods", func() {
+				req := createRequest("GET", "/search?page=2&big=9000000000&ratio=0.5&draft=true", nil, nil)
+
+				page, err := req.GetQueryInt("page")
+				Expect(err).To(BeNil())
+				Expect(page).To(Equal(2))
+
+				big, err := req.GetQueryInt64("big")
+				Expect(err).To(BeNil())
+				Expect(big).To(Equal(int64(9000000000)))
+
+				ratio, err := req.GetQueryFloat64("ratio")
+				Expect(err).To(BeNil())
+				Expect(ratio).To(Equal(0.5))
+
+				draft, err := req.GetQueryBool("draft")
+				Expect(err).To(BeNil())
+				Expect(draft).To(BeTrue())
+			})
+
+			It("should return a ParamError when a value cannot be converted", func() {
+				req := createRequest("GET", "/search?page=two", nil, nil)
+
+				_, err := req.GetQueryInt("page")
+				paramErr, ok := err.(*ParamError)
+				Expect(ok).To(BeTrue())
+				Expect(paramErr.Source).To(Equal("query"))
+				Expect(paramErr.Name).To(Equal("page"))
+				Expect(paramErr.Value).To(Equal("two"))
+				Expect(err.Error()).To(Equal(`query parameter "page" must be a valid int, got "two"`))
+			})
+		})
+
+		When("a query parameter is present without a value", func() {
+			It("should still exist", func() {
+				req := createRequest("GET", "/search?flag&empty=", nil, nil)
+
+				Expect(req.QueryExists("flag")).To(BeTrue())
+				Expect(req.QueryExists("empty")).To(BeTrue())
+				Expect(req.GetQuery("empty")).To(Equal(""))
+			})
+
+			It("should return a conversion error rather than ErrParamMissing from the typed methods", func() {
+				req := createRequest("GET", "/search?page=", nil, nil)
+
+				_, err := req.GetQueryInt("page")
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Is(err, ErrParamMissing)).To(BeFalse())
+				Expect(err.Error()).To(Equal(`query parameter "page" must be a valid int, got ""`))
+			})
+		})
+
+		When("a query parameter is not present", func() {
+			It("should cause the QueryExists method to return false", func() {
+				req := createRequest("GET", "/search", nil, nil)
+
+				Expect(req.QueryExists("missing")).To(BeFalse())
+				Expect(req.GetQuery("missing")).To(Equal(""))
+				Expect(req.GetQueryAll("missing")).To(BeEmpty())
+			})
+
+			It("should return ErrParamMissing from the typed methods", func() {
+				req := createRequest("GET", "/search", nil, nil)
+
+				_, err := req.GetQueryInt("page")
+				Expect(errors.Is(err, ErrParamMissing)).To(BeTrue())
+			})
+		})
+
+		When("a request is created with CreateRequest", func() {
+			It("should make the query string visible to handlers", func() {
+				handler := func(request Request) Response {
+					return request.Success(request.GetQuery("name"))
+				}
+
+				response := handler(CreateRequest("GET", "/user?name=bob", nil, nil))
+				Expect(string(response.GetResponseContent())).To(Equal("bob"))
+			})
+		})
+	})
+
 	Context("Request information", func() {
 		When("the GetURL method is called", func() {
 			It("should return the url of the request", func() {
@@ -194,4 +364,61 @@ var _ = Describe("Router unit tests", func() {
 			})
 		})
 	})
+
+	Context("Request context", func() {
+		When("the Context method is called", func() {
+			It("should return the context of the underlying HTTP request", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				r := httptest.NewRequest("GET", "/", nil).WithContext(ctx)
+				req := createRequestAdvanced(r, nil)
+
+				cancel()
+				Expect(req.Context().Err()).To(Equal(context.Canceled))
+			})
+		})
+
+		When("the SetContext method is called", func() {
+			It("should replace the context seen by later callers", func() {
+				req := createRequest("GET", "/", nil, nil)
+				ctx, cancel := context.WithTimeout(req.Context(), time.Minute)
+				defer cancel()
+
+				req.SetContext(ctx)
+				_, hasDeadline := req.Context().Deadline()
+				Expect(hasDeadline).To(BeTrue())
+			})
+
+			It("should be visible to handlers after middleware replaces it", func() {
+				type key string
+				router := Router{}
+				router.Use(func(handler Handler) Handler {
+					return func(request Request) Response {
+						request.SetContext(context.WithValue(request.Context(), key("user"), "bob"))
+						return handler(request)
+					}
+				})
+				router.Get("/", func(request Request) Response {
+					return request.Success(request.Context().Value(key("user")))
+				})
+
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+				Expect(w.Body.String()).To(Equal("bob"))
+			})
+		})
+
+		When("values are stored on the request", func() {
+			It("should return them via the Get method", func() {
+				req := createRequest("GET", "/", nil, nil)
+				req.Set("user", 42)
+
+				Expect(req.Get("user")).To(Equal(42))
+			})
+
+			It("should return nil for a value which was never set", func() {
+				req := createRequest("GET", "/", nil, nil)
+				Expect(req.Get("missing")).To(BeNil())
+			})
+		})
+	})
 })
@@ -9,204 +9,379 @@ import (
 	stdLog "log"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type Router struct {
-	routes   []route
-	notFound Handler
-	root     string
+	routes           []*route
+	trees            map[string]*node
+	notFound         Handler
+	methodNotAllowed Handler
+	roots            []string
+	cors             *CORSPolicy
+	corsDisabled     bool
+	middleware       []Middleware
+	groups           []*Group
+	mounts           []*Group
+	hosts            []*hostRouter
+	parent           *Router
+	paths            *PathOptions
+	problems         bool
+	panicHandler     func(request Request, recovered interface{}) Response
+	log              Log
+	onShutdown       []func()
+	draining         int32
+	shapes           map[string]*route
+	names            map[string]*route
+	conflicts        []string
+	slashConflicts   []string
 }
 
-func (r *Router) Get(path string, handler Handler) {
-	r.url("GET", path, handler)
+func (r *Router) Get(path string, handler Handler, options ...RouteOption) {
+	r.url("GET", path, handler, options...)
 }
 
-func (r *Router) Post(path string, handler Handler) {
-	r.url("POST", path, handler)
+func (r *Router) Post(path string, handler Handler, options ...RouteOption) {
+	r.url("POST", path, handler, options...)
 }
 
-func (r *Router) Put(path string, handler Handler) {
-	r.url("PUT", path, handler)
+func (r *Router) Put(path string, handler Handler, options ...RouteOption) {
+	r.url("PUT", path, handler, options...)
 }
 
-func (r *Router) Patch(path string, handler Handler) {
-	r.url("PATCH", path, handler)
+func (r *Router) Patch(path string, handler Handler, options ...RouteOption) {
+	r.url("PATCH", path, handler, options...)
 }
 
-func (r *Router) Delete(path string, handler Handler) {
-	r.url("DELETE", path, handler)
+func (r *Router) Delete(path string, handler Handler, options ...RouteOption) {
+	r.url("DELETE", path, handler, options...)
 }
 
-func (r *Router) Route(method, path string, handler Handler) {
-	r.url(method, path, handler)
+// Head registers a HEAD handler. Without one, HEAD requests are served by the
+// GET handler with the body discarded.
+func (r *Router) Head(path string, handler Handler, options ...RouteOption) {
+	r.url("HEAD", path, handler, options...)
+}
+
+// Options registers an OPTIONS handler, which replaces the automatic CORS
+// preflight response for the route.
+func (r *Router) Options(path string, handler Handler, options ...RouteOption) {
+	r.url("OPTIONS", path, handler, options...)
+}
+
+func (r *Router) Route(method, path string, handler Handler, options ...RouteOption) {
+	r.url(method, path, handler, options...)
 }
 
 func (r *Router) NotFound(handler Handler) {
 	r.notFound = handler
 }
 
-func (r *Router) Root(urlRoot string) {
-	r.root = urlRoot
+func (r *Router) MethodNotAllowed(handler Handler) {
+	r.methodNotAllowed = handler
+}
+
+func (r *Router) notFoundHandler() Handler {
+	for ; r != nil; r = r.parent {
+		if r.notFound != nil {
+			return r.notFound
+		}
+	}
+	return nil
+}
+
+func (r *Router) methodNotAllowedHandler() Handler {
+	for ; r != nil; r = r.parent {
+		if r.methodNotAllowed != nil {
+			return r.methodNotAllowed
+		}
+	}
+	return nil
+}
+
+// Root serves the router's routes beneath one or more URL prefixes, such as
+// "/api". Requests outside of every root are answered by the NotFound handler.
+func (r *Router) Root(roots ...string) {
+	r.roots = make([]string, 0, len(roots))
+	for _, root := range roots {
+		cleaned, err := cleanRoot(root)
+		if err != nil {
+			panic("router: " + err.Error())
+		}
+
+		if cleaned != "/" && !contains(r.roots, cleaned) {
+			r.roots = append(r.roots, cleaned)
+		}
+	}
 }
 
-func (r *Router) url(method, path string, handler Handler) {
+func (r *Router) url(method, path string, handler Handler, options ...RouteOption) {
 	if len(r.routes) < 1 {
-		r.routes = make([]route, 0)
+		r.routes = make([]*route, 0)
+	}
+
+	if r.trees == nil {
+		r.trees = make(map[string]*node)
+	}
+
+	method = strings.ToUpper(method)
+	if _, exists := r.trees[method]; !exists {
+		r.trees[method] = &node{}
 	}
 
-	r.routes = append(r.routes, route{Method: method, Path: path, Handler: handler})
+	rt := &route{Method: method, Path: path, Handler: handler}
+	for _, option := range options {
+		option(rt)
+	}
+
+	tokens, err := tokenise(path)
+	if err != nil {
+		panic("router: " + err.Error())
+	}
+	rt.tokens = tokens
+
+	r.checkConflicts(rt, tokens)
+	r.registerName(rt)
+	r.trees[method].insert(tokens, rt)
+	r.routes = append(r.routes, rt)
+
+	for _, mount := range r.mounts {
+		mount.router.mountRoute(mount, rt)
+	}
 }
 
-func (rt *Router) Serve(port int) error {
-	return http.ListenAndServe(fmt.Sprintf(":%d", port), rt)
+func (rt *Router) Serve(port int, opts ...ServerOptions) error {
+	if err := rt.Validate(); err != nil {
+		return err
+	}
+	return rt.server(fmt.Sprintf(":%d", port), nil, opts).ListenAndServe()
 }
 
-func (rt *Router) ServeWithTLS(port int, key, cert string) error {
-	var err error
-	if len(key) < 1 || len(cert) < 1 {
-		if key, cert, err = rt.generateTLSCerts(); err != nil {
-			return err
-		}
+func (rt *Router) ServeWithTLS(port int, key, cert string, opts ...ServerOptions) error {
+	err := rt.Validate()
+	if err != nil {
+		return err
+	}
+
+	if key, cert, err = rt.tlsFiles(key, cert); err != nil {
+		return err
 	}
 
 	suppression := stdLog.New(ioutil.Discard, "/", 0)
-	s := http.Server{}
-	s.Addr = fmt.Sprintf(":%d", port)
-	s.Handler = rt
-	s.ErrorLog = suppression
-	return s.ListenAndServeTLS(cert, key)
+	return rt.server(fmt.Sprintf(":%d", port), suppression, opts).ListenAndServeTLS(cert, key)
 }
 
-func (rt *Router) ServeIP(ip string, port int) error {
-	return http.ListenAndServe(fmt.Sprintf("%s:%d", ip, port), rt)
+func (rt *Router) ServeIP(ip string, port int, opts ...ServerOptions) error {
+	if err := rt.Validate(); err != nil {
+		return err
+	}
+	return rt.server(fmt.Sprintf("%s:%d", ip, port), nil, opts).ListenAndServe()
 }
 
 func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if r.Method == "OPTIONS" {
-		rt.corsInjector(w)
-		w.WriteHeader(http.StatusOK)
+	if len(rt.hosts) > 0 {
+		if host, args := rt.matchHost(r.Host); host != nil {
+			host.serve(w, r, args)
+			return
+		}
+	}
+	rt.serve(w, r, nil)
+}
+
+func (rt *Router) serve(w http.ResponseWriter, r *http.Request, hostArgs map[string]string) {
+	if r.Method == "OPTIONS" && !rt.corsOff() && rt.lookup("OPTIONS", r.URL.Path).route == nil {
+		rt.preflight(w, r)
 		return
 	}
 
-	foundHandler, params, err := rt.findHandler(r)
-	if err != nil {
-		if rt.notFound != nil {
-			foundHandler = rt.notFound
-		} else {
-			w.WriteHeader(404)
-			if _, err = w.Write([]byte("No provider could be found")); err != nil {
-				fmt.Printf("Error writing HTTP response : %s\n", err.Error())
-			}
-			return
+	if target := rt.canonicalPath(r); len(target) > 0 {
+		rt.redirectPath(w, r, target)
+		return
+	}
+
+	var foundHandler Handler
+	var scope *Group
+	foundRoute, params, err := rt.findHandler(r)
+
+	if err == nil {
+		foundHandler = foundRoute.Handler
+	} else if allowed := rt.allowedMethods(r); len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		foundHandler = methodNotAllowed
+		if handler := rt.methodNotAllowedHandler(); handler != nil {
+			foundHandler = handler
 		}
+	} else if scope = rt.notFoundGroup(r.URL.Path); scope != nil {
+		foundHandler = scope.notFoundHandler()
+	} else if handler := rt.notFoundHandler(); handler != nil {
+		foundHandler = handler
+	} else {
+		foundHandler = notFound
 	}
 
+	relativeURL, _ := rt.relativePath(r.URL.Path)
 	req := request{
-		input:     r,
-		args:      params,
-		Host:      r.Host,
-		URL:       r.URL.Path,
-		UserAgent: r.Header.Get("User-Agent"),
+		input:       r,
+		args:        mergeArgs(hostArgs, params),
+		Host:        r.Host,
+		URL:         r.URL.Path,
+		RelativeURL: relativeURL,
+		UserAgent:   r.Header.Get("User-Agent"),
 	}
 
-	resp := foundHandler(&req)
+	req.writer = w
+	req.problems = rt.problemDetails()
+	req.log = rt.logger()
+	defer req.removeForms()
 
 	if len(os.Getenv("BuildDate")) > 0 {
 		w.Header().Set("X-Build-Date", os.Getenv("BuildDate"))
 	}
 
-	rt.corsInjector(w)
+	if !rt.corsOff() {
+		rt.corsPolicy(foundRoute).inject(w, r)
+	}
+
+	resp := rt.handle(rt.wrap(foundHandler, foundRoute, scope), &req)
+	if req.written {
+		return
+	}
 
+	if err = writeResponse(w, r, resp); err != nil {
+		rt.logError("Router : Error writing HTTP response", ":", err.Error())
+	}
+}
+
+func writeResponse(w http.ResponseWriter, r *http.Request, resp Response) error {
 	if len(resp.GetResponseRedirect()) > 0 {
 		http.Redirect(w, r, resp.GetResponseRedirect(), resp.GetResponseStatusCode())
-		return
+		return nil
 	}
 
 	for key, val := range resp.GetResponseHeaders() {
 		w.Header().Set(key, val)
 	}
 
-	w.WriteHeader(resp.GetResponseStatusCode())
-	if _, err = w.Write(resp.GetResponseContent()); err != nil {
-		fmt.Printf("Error writing HTTP response : %s\n", err.Error())
+	content := resp.GetResponseContent()
+	if r.Method == "HEAD" {
+		if len(content) > 0 && len(w.Header().Get("Content-Length")) < 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		}
+		w.WriteHeader(resp.GetResponseStatusCode())
+		return nil
 	}
+
+	w.WriteHeader(resp.GetResponseStatusCode())
+	_, err := w.Write(content)
+	return err
 }
 
-func (rt *Router) findHandler(r *http.Request) (Handler, map[string]string, error) {
-	for _, route := range rt.routes {
-		if !strings.EqualFold(r.Method, route.Method) {
-			continue
-		}
+func notFound(request Request) Response {
+	return failure(request, http.StatusNotFound, "No provider could be found")
+}
 
-		match, args := rt.isAMatch(route.Path, r.URL.Path)
+func methodNotAllowed(request Request) Response {
+	return failure(request, http.StatusMethodNotAllowed, "Method not allowed")
+}
 
-		if match {
-			return route.Handler, args, nil
-		}
+func (rt *Router) findHandler(r *http.Request) (*route, map[string]string, error) {
+	m := rt.lookup(r.Method, r.URL.Path)
+	if m.route == nil && r.Method == "HEAD" {
+		m = rt.lookup("GET", r.URL.Path)
 	}
 
-	return nil, nil, errors.New("no_handler")
-}
-
-func (r *Router) isAMatch(path, url string) (bool, map[string]string) {
-	if len(r.root) > 0 {
-		url = url[len(r.root):]
+	if m.route == nil {
+		return nil, nil, errors.New("no_handler")
 	}
+	return m.route, m.args(), nil
+}
 
-	urlBits := strings.Split(strings.Trim(url, "/"), "/")
-	pathBits := strings.Split(strings.Trim(path, "/"), "/")
+func (rt *Router) allowedMethods(r *http.Request) []string {
+	allowed := make([]string, 0)
 
-	mandatoryBits := 0
-	for _, bit := range pathBits {
-		if strings.Contains(bit, "[") && strings.Contains(bit, "]") {
-			mandatoryBits++
+	for method := range rt.trees {
+		if m := rt.lookup(method, r.URL.Path); m.route != nil {
+			allowed = append(allowed, method)
 		}
 	}
 
-	if len(urlBits) < mandatoryBits || len(urlBits) > len(pathBits) {
-		return false, nil
+	if contains(allowed, "GET") && !contains(allowed, "HEAD") {
+		allowed = append(allowed, "HEAD")
 	}
 
-	match := true
-	args := make(map[string]string)
-	for pos, bit := range pathBits {
+	if len(allowed) > 0 && !rt.corsOff() && !contains(allowed, "OPTIONS") {
+		allowed = append(allowed, "OPTIONS")
+	}
 
-		if len(urlBits) < pos+1 {
-			if strings.Contains(bit, "[:") {
-				break
+	sort.Strings(allowed)
+	return allowed
+}
+
+func (rt *Router) match(method, url string) matcher {
+	m := matcher{slash: hasTrailingSlash(url), strict: rt.pathOptions().TrailingSlash == TrailingSlashStrict}
+	if tree, exists := rt.trees[strings.ToUpper(method)]; exists {
+		m.search(tree, cleanPath(url))
+	}
+	return m
+}
+
+// relativePath strips the longest matching root from a URL, reporting false
+// when the URL is not beneath any of the router's roots.
+func (rt *Router) relativePath(url string) (string, bool) {
+	if len(rt.roots) < 1 {
+		return url, true
+	}
 
-			} else {
-				args = nil
-				match = false
-				break
-			}
+	matched := ""
+	for _, root := range rt.roots {
+		if len(root) > len(matched) && (url == root || strings.HasPrefix(url, root+"/")) {
+			matched = root
 		}
+	}
 
-		if match && len(bit) > 1 && bit[0:1] == ":" {
-			args[bit[1:]] = urlBits[pos]
+	if len(matched) < 1 {
+		return "", false
+	}
 
-		} else if match && len(bit) > 1 && bit[0:2] == "[:" {
-			args[bit[2:len(bit)-1]] = urlBits[pos]
+	if relative := url[len(matched):]; len(relative) > 0 {
+		return relative, true
+	}
+	return "/", true
+}
 
-		} else {
+// root is the prefix used when building URLs, which is the first root given.
+func (rt *Router) root() string {
+	if len(rt.roots) < 1 {
+		return ""
+	}
+	return rt.roots[0]
+}
 
-			if urlBits[pos] != pathBits[pos] {
-				args = nil
-				match = false
-				break
-			}
-		}
+func cleanRoot(root string) (string, error) {
+	trimmed := strings.Trim(root, "/")
+	if len(trimmed) < 1 {
+		return "/", nil
 	}
 
-	return match, args
+	for _, segment := range strings.Split(trimmed, "/") {
+		switch {
+		case len(segment) < 1, segment == ".", segment == "..":
+			return "", fmt.Errorf("root %q must be a clean path", root)
+		case strings.ContainsAny(segment[0:1], ":[*"), strings.ContainsAny(segment, "?#"):
+			return "", fmt.Errorf("root %q cannot contain parameters, wildcards or a query", root)
+		}
+	}
+	return "/" + trimmed, nil
 }
 
-func (r *Router) corsInjector(w http.ResponseWriter) {
-	w.Header().Set("Access-Control-Allow-Headers", "*")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+func (r *Router) tlsFiles(key, cert string) (string, string, error) {
+	if len(key) < 1 || len(cert) < 1 {
+		return r.generateTLSCerts()
+	}
+	return key, cert, nil
 }
 
 func (r *Router) generateTLSCerts() (string, string, error) {
@@ -225,3 +400,12 @@ func (r *Router) generateTLSCerts() (string, string, error) {
 	}
 	return "private.key", "self.cert", nil
 }
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
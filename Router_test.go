@@ -45,7 +45,7 @@ var _ = Describe("Router unit tests", func() {
 					w := httptest.NewRecorder()
 					router.ServeHTTP(w, r)
 
-					Expect(w.Result().StatusCode).To(Equal(404))
+					Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
 				})
 			})
 
@@ -72,7 +72,7 @@ var _ = Describe("Router unit tests", func() {
 					w := httptest.NewRecorder()
 					router.ServeHTTP(w, r)
 
-					Expect(w.Result().StatusCode).To(Equal(404))
+					Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
 				})
 			})
 
@@ -99,7 +99,7 @@ var _ = Describe("Router unit tests", func() {
 					w := httptest.NewRecorder()
 					router.ServeHTTP(w, r)
 
-					Expect(w.Result().StatusCode).To(Equal(404))
+					Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
 				})
 			})
 
@@ -126,7 +126,7 @@ var _ = Describe("Router unit tests", func() {
 					w := httptest.NewRecorder()
 					router.ServeHTTP(w, r)
 
-					Expect(w.Result().StatusCode).To(Equal(404))
+					Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
 				})
 			})
 
@@ -153,7 +153,7 @@ var _ = Describe("Router unit tests", func() {
 					w := httptest.NewRecorder()
 					router.ServeHTTP(w, r)
 
-					Expect(w.Result().StatusCode).To(Equal(404))
+					Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
 				})
 			})
 
@@ -180,7 +180,7 @@ var _ = Describe("Router unit tests", func() {
 					w := httptest.NewRecorder()
 					router.ServeHTTP(w, r)
 
-					Expect(w.Result().StatusCode).To(Equal(404))
+					Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
 				})
 			})
 		})
@@ -250,6 +250,56 @@ var _ = Describe("Router unit tests", func() {
 				Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
 				Expect(w.Body.String()).To(Equal("OK"))
 			})
+
+			It("should serve up a 404 for URLs outside of the prefix instead of panicking", func() {
+				router.Get("/here", func(request Request) Response {
+					return request.Success("OK")
+				})
+				router.Root("/my/url/prefix")
+
+				for _, url := range []string{"/", "/my", "/other/url/prefix/here", "/my/url/prefixed/here", "/here"} {
+					r := httptest.NewRequest("GET", url, nil)
+					w := httptest.NewRecorder()
+					router.ServeHTTP(w, r)
+
+					Expect(w.Result().StatusCode).To(Equal(http.StatusNotFound), url)
+				}
+			})
+
+			It("should serve routes beneath every prefix and report both URLs", func() {
+				router.Get("/here", func(request Request) Response {
+					return request.Success(request.GetURL() + " " + request.GetRelativeURL())
+				})
+				router.Root("/api", "/internal/api/")
+
+				for _, url := range []string{"/api/here", "/internal/api/here"} {
+					r := httptest.NewRequest("GET", url, nil)
+					w := httptest.NewRecorder()
+					router.ServeHTTP(w, r)
+
+					Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+					Expect(w.Body.String()).To(Equal(url + " /here"))
+				}
+			})
+
+			It("should serve the root route at the prefix itself", func() {
+				router.Get("/", func(request Request) Response {
+					return request.Success("index")
+				})
+				router.Root("/api")
+
+				r := httptest.NewRequest("GET", "/api", nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, r)
+
+				Expect(w.Body.String()).To(Equal("index"))
+			})
+
+			It("should panic when the prefix is not a plain path", func() {
+				for _, root := range []string{"/api/:version", "/api//v1", "/api/../v1", "/*rest", "/api?x=1"} {
+					Expect(func() { router.Root(root) }).To(PanicWith(ContainSubstring("router: ")), root)
+				}
+			})
 		})
 
 		When("the router is serving up an error state", func() {
@@ -266,6 +316,53 @@ var _ = Describe("Router unit tests", func() {
 			})
 		})
 
+		When("the request matches a route registered under a different method", func() {
+			It("should serve up a 405 response with an Allow header", func() {
+				router.Get("/user/:id", func(request Request) Response {
+					return request.Success("OK")
+				})
+				router.Delete("/user/:id", func(request Request) Response {
+					return request.Success("OK")
+				})
+
+				r := httptest.NewRequest("POST", "/user/12", nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, r)
+
+				Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
+				Expect(w.Result().Header.Get("Allow")).To(Equal("DELETE, GET, HEAD, OPTIONS"))
+			})
+
+			It("should serve the MethodNotAllowed function when one is given", func() {
+				router.Get("/user/:id", func(request Request) Response {
+					return request.Success("OK")
+				})
+				router.MethodNotAllowed(func(request Request) Response {
+					return request.Error(http.StatusMethodNotAllowed, "method not allowed handled correctly")
+				})
+
+				r := httptest.NewRequest("POST", "/user/12", nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, r)
+
+				Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
+				Expect(w.Result().Header.Get("Allow")).To(Equal("GET, HEAD, OPTIONS"))
+				Expect(w.Body.String()).To(Equal("method not allowed handled correctly"))
+			})
+
+			It("should still serve a 404 response when no method matches the URL", func() {
+				router.Get("/user/:id", func(request Request) Response {
+					return request.Success("OK")
+				})
+
+				r := httptest.NewRequest("POST", "/account/12", nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, r)
+
+				Expect(w.Result().StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
 		When("the router is given a NotFound function", func() {
 			It("should serve that function whenever a URL is not defined as a route", func() {
 				router.NotFound(func(request Request) Response {
@@ -0,0 +1,130 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+type argsKey struct{}
+
+// Handle registers a standard library http.Handler. Path arguments are
+// available to it through Args(r.Context()). Middleware still runs around the
+// handler, but the handler writes its response directly so anything set on the
+// Response after it returns is ignored.
+func (r *Router) Handle(method, path string, handler http.Handler, options ...RouteOption) {
+	r.url(method, path, fromHTTP(handler), append([]RouteOption{wrapping(handler)}, options...)...)
+}
+
+func (r *Router) HandleFunc(method, path string, handler func(w http.ResponseWriter, r *http.Request), options ...RouteOption) {
+	r.Handle(method, path, http.HandlerFunc(handler), options...)
+}
+
+func (g *Group) Handle(method, path string, handler http.Handler, options ...RouteOption) {
+	g.Route(method, path, fromHTTP(handler), append([]RouteOption{wrapping(handler)}, options...)...)
+}
+
+func (g *Group) HandleFunc(method, path string, handler func(w http.ResponseWriter, r *http.Request), options ...RouteOption) {
+	g.Handle(method, path, http.HandlerFunc(handler), options...)
+}
+
+// Args returns the path arguments of a request served through Handle or
+// HandleFunc.
+func Args(ctx context.Context) map[string]string {
+	args, _ := ctx.Value(argsKey{}).(map[string]string)
+	return args
+}
+
+// Adapt turns a Handler into an http.HandlerFunc so it can be used with other
+// muxes. Path arguments stored with Args are passed on to the handler.
+func Adapt(handler Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{
+			input:       r,
+			writer:      w,
+			args:        Args(r.Context()),
+			Host:        r.Host,
+			URL:         r.URL.Path,
+			RelativeURL: r.URL.Path,
+			UserAgent:   r.Header.Get("User-Agent"),
+		}
+		defer req.removeForms()
+
+		resp := handler(req)
+		if req.written {
+			return
+		}
+		_ = writeResponse(w, r, resp)
+	}
+}
+
+func wrapping(handler http.Handler) RouteOption {
+	return func(rt *route) {
+		rt.httpHandler = handler
+	}
+}
+
+func fromHTTP(handler http.Handler) Handler {
+	return func(in Request) Response {
+		req, isRequest := in.(*request)
+		if !isRequest || req.writer == nil {
+			return in.Error(http.StatusInternalServerError, "http.Handler routes can only be served by a Router")
+		}
+
+		for key, val := range req.GetResponseHeaders() {
+			req.writer.Header().Set(key, val)
+		}
+
+		ctx := context.WithValue(req.Context(), argsKey{}, req.args)
+		writer := &trackingWriter{ResponseWriter: req.writer}
+
+		// A panicking handler which has not written anything yet can still
+		// be answered by the panic handler
+		defer func() {
+			req.written = req.written || writer.wrote
+		}()
+
+		handler.ServeHTTP(writer, req.input.WithContext(ctx))
+		req.written = true
+		return req
+	}
+}
+
+// trackingWriter records whether a response has been started, while still
+// allowing handlers to flush and hijack the connection.
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *trackingWriter) WriteHeader(statusCode int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *trackingWriter) Write(content []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(content)
+}
+
+func (w *trackingWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wrote = true
+		flusher.Flush()
+	}
+}
+
+func (w *trackingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("router: the response writer cannot be hijacked")
+	}
+	w.wrote = true
+	return hijacker.Hijack()
+}
+
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,116 @@
+package router
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("net/http adapter unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	serve := func(handler http.Handler, method, url string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	When("an http.Handler is registered", func() {
+		It("should serve it with the path arguments in the request context", func() {
+			router.HandleFunc("GET", "/hooks/:provider", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Provider", Args(r.Context())["provider"])
+				w.WriteHeader(http.StatusAccepted)
+				fmt.Fprint(w, "received")
+			})
+
+			Expect(router.Routes()[0].Handler).To(HavePrefix("github.com/driscollcode/router."))
+			Expect(router.Routes()[0].Handler).ToNot(ContainSubstring("fromHTTP"))
+
+			w := serve(&router, "GET", "/hooks/github")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusAccepted))
+			Expect(w.Result().Header.Get("X-Provider")).To(Equal("github"))
+			Expect(w.Body.String()).To(Equal("received"))
+		})
+
+		It("should run middleware and keep the headers it sets", func() {
+			router.Use(func(handler Handler) Handler {
+				return func(request Request) Response {
+					request.SetHeader("X-Middleware", "ran")
+					request.Set("user", "ada")
+					return handler(request)
+				}
+			})
+			router.Handle("GET", "/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "OK")
+			}))
+			router.Handle("GET", "/files", http.FileServer(http.Dir(".")))
+			Expect(router.Routes()[1].Handler).To(Equal("*http.fileHandler"))
+
+			w := serve(&router, "GET", "/")
+			Expect(w.Body.String()).To(Equal("OK"))
+			Expect(w.Result().Header.Get("X-Middleware")).To(Equal("ran"))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		})
+
+		It("should be registered inside groups", func() {
+			router.Group("/debug", func(g *Group) {
+				g.HandleFunc("GET", "/vars", func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, "vars")
+				})
+			})
+
+			Expect(serve(&router, "GET", "/debug/vars").Body.String()).To(Equal("vars"))
+		})
+
+		It("should answer a panic with a 500 when nothing has been written", func() {
+			router.Logger(&recordingLog{})
+			router.HandleFunc("GET", "/", func(w http.ResponseWriter, r *http.Request) {
+				panic("broken")
+			})
+
+			Expect(serve(&router, "GET", "/").Result().StatusCode).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should not write over a response which has already started", func() {
+			router.Logger(&recordingLog{})
+			router.HandleFunc("GET", "/", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				panic("broken")
+			})
+
+			w := serve(&router, "GET", "/")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusCreated))
+			Expect(w.Body.String()).To(BeEmpty())
+		})
+	})
+
+	When("a Handler is adapted for another mux", func() {
+		It("should serve the Handler's response", func() {
+			mux := http.NewServeMux()
+			mux.Handle("/hello", Adapt(func(request Request) Response {
+				request.SetHeader("X-Name", request.GetQuery("name"))
+				return request.Success(http.StatusCreated, "hello "+request.GetQuery("name"))
+			}))
+
+			w := serve(mux, "GET", "/hello?name=ada")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusCreated))
+			Expect(w.Result().Header.Get("X-Name")).To(Equal("ada"))
+			Expect(w.Body.String()).To(Equal("hello ada"))
+		})
+
+		It("should pass path arguments through when nested in a Router", func() {
+			router.Handle("GET", "/users/:id", Adapt(func(request Request) Response {
+				return request.Success("user " + request.GetArg("id"))
+			}))
+
+			Expect(serve(&router, "GET", "/users/7").Body.String()).To(Equal("user 7"))
+		})
+	})
+})
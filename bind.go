@@ -0,0 +1,347 @@
+package router
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// multipartMemory is how much of a multipart form is held in memory before
+// uploaded files are written to temporary files.
+var multipartMemory int64 = 32 << 20
+
+var (
+	ErrUnsupportedMediaType = errors.New("unsupported content type")
+	ErrUnknownField         = errors.New("unknown field")
+)
+
+// BindError describes why Bind could not fill a struct. Source is "body",
+// "form", "path" or "query" and Field is the name the value was sent under.
+// Type is set when a value could not be converted to the field's type.
+type BindError struct {
+	Source string
+	Field  string
+	Type   string
+	Err    error
+}
+
+func (e *BindError) Error() string {
+	switch {
+	case errors.Is(e.Err, ErrUnsupportedMediaType):
+		return fmt.Sprintf("%s: %v", e.Source, e.Err)
+	case errors.Is(e.Err, ErrUnknownField):
+		return fmt.Sprintf("%s field %q is not recognised", e.Source, e.Field)
+	case len(e.Type) > 0:
+		return fmt.Sprintf("%s field %q must be a valid %s", e.Source, e.Field, e.Type)
+	case len(e.Field) > 0:
+		return fmt.Sprintf("%s field %q: %v", e.Source, e.Field, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Source, e.Err)
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	fileHeaderType  = reflect.TypeOf(&multipart.FileHeader{})
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader{})
+	unmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Bind decodes the request body into dst, a pointer to a struct, choosing the
+// decoder from the Content-Type header. JSON and form bodies may not contain
+// fields dst doesn't have, while XML elements without a matching field are
+// ignored. Form values are matched to fields with a `form` tag, and path
+// arguments and query parameters are then copied into fields with `path` and
+// `query` tags. Uploaded files which were written to disk are removed once the
+// handler returns, so they must be read or copied before then.
+func (r *request) Bind(dst interface{}) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("router: Bind requires a pointer to a struct, got %T", dst)
+	}
+
+	if err := r.bindBody(dst, target.Elem()); err != nil {
+		return err
+	}
+
+	if err := bindValues(target.Elem(), "query", r.queryValues()); err != nil {
+		return err
+	}
+
+	args := make(url.Values, len(r.args))
+	for name, value := range r.args {
+		args.Set(name, value)
+	}
+	return bindValues(target.Elem(), "path", args)
+}
+
+func (r *request) bindBody(dst interface{}, target reflect.Value) error {
+	if !r.HasBody() {
+		if err := r.BodyError(); err != nil {
+			return &BindError{Source: "body", Err: err}
+		}
+		return nil
+	}
+
+	contentType := r.GetHeader("Content-Type")
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if len(contentType) > 0 && err != nil {
+		return &BindError{Source: "body", Err: fmt.Errorf("%w %q", ErrUnsupportedMediaType, contentType)}
+	}
+
+	switch {
+	case len(contentType) < 1, mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return bindJSON(r.Body(), dst)
+	case mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		if err := xml.Unmarshal(r.Body(), dst); err != nil {
+			return &BindError{Source: "body", Err: err}
+		}
+		return nil
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(r.Body()))
+		if err != nil {
+			return &BindError{Source: "form", Err: err}
+		}
+
+		if err = checkFormFields(target.Type(), values, nil); err != nil {
+			return err
+		}
+		return bindValues(target, "form", values)
+	case mediaType == "multipart/form-data":
+		form, err := multipart.NewReader(bytes.NewReader(r.Body()), params["boundary"]).ReadForm(multipartMemory)
+		if err != nil {
+			return &BindError{Source: "form", Err: err}
+		}
+		r.forms = append(r.forms, form)
+
+		if err = checkFormFields(target.Type(), form.Value, form.File); err != nil {
+			return err
+		}
+
+		if err = bindValues(target, "form", form.Value); err != nil {
+			return err
+		}
+		return bindFiles(target, form.File)
+	}
+	return &BindError{Source: "body", Err: fmt.Errorf("%w %q", ErrUnsupportedMediaType, mediaType)}
+}
+
+// removeForms deletes the temporary files of every multipart form bound
+// during the request.
+func (r *request) removeForms() {
+	for _, form := range r.forms {
+		_ = form.RemoveAll()
+	}
+	r.forms = nil
+}
+
+func bindJSON(body []byte, dst interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(dst)
+	if err == nil {
+		if decoder.More() {
+			return &BindError{Source: "body", Err: errors.New("unexpected data after the JSON value")}
+		}
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr):
+		return &BindError{Source: "body", Field: typeErr.Field, Type: typeErr.Type.String(), Err: err}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		return &BindError{Source: "body", Field: field, Err: ErrUnknownField}
+	case errors.Is(err, io.EOF):
+		return &BindError{Source: "body", Err: io.ErrUnexpectedEOF}
+	}
+	return &BindError{Source: "body", Err: err}
+}
+
+// checkFormFields reports the first form value or file, in name order, which
+// no field of target is tagged to receive.
+func checkFormFields(target reflect.Type, values url.Values, files map[string][]*multipart.FileHeader) error {
+	known := make(map[string]bool)
+	formFields(target, known)
+
+	names := make([]string, 0, len(values)+len(files))
+	for name := range values {
+		names = append(names, name)
+	}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !known[name] {
+			return &BindError{Source: "form", Field: name, Err: ErrUnknownField}
+		}
+	}
+	return nil
+}
+
+func formFields(target reflect.Type, known map[string]bool) {
+	for pos := 0; pos < target.NumField(); pos++ {
+		field := target.Field(pos)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			formFields(field.Type, known)
+			continue
+		}
+
+		if name := field.Tag.Get("form"); len(name) > 0 && name != "-" && len(field.PkgPath) < 1 {
+			known[name] = true
+		}
+	}
+}
+
+// bindValues copies values into the fields of target tagged with source,
+// descending into embedded structs.
+func bindValues(target reflect.Value, source string, values url.Values) error {
+	if len(values) < 1 {
+		return nil
+	}
+
+	for pos := 0; pos < target.NumField(); pos++ {
+		field, value := target.Type().Field(pos), target.Field(pos)
+
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			if err := bindValues(value, source, values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get(source)
+		if len(name) < 1 || name == "-" || len(field.PkgPath) > 0 {
+			continue
+		}
+
+		raw, exists := values[name]
+		if !exists || len(raw) < 1 {
+			continue
+		}
+
+		if err := setField(value, raw); err != nil {
+			return &BindError{Source: source, Field: name, Type: field.Type.String(), Err: err}
+		}
+	}
+	return nil
+}
+
+func bindFiles(target reflect.Value, files map[string][]*multipart.FileHeader) error {
+	for pos := 0; pos < target.NumField(); pos++ {
+		field, value := target.Type().Field(pos), target.Field(pos)
+
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			if err := bindFiles(value, files); err != nil {
+				return err
+			}
+			continue
+		}
+
+		uploaded := files[field.Tag.Get("form")]
+		if len(uploaded) < 1 || len(field.PkgPath) > 0 {
+			continue
+		}
+
+		switch field.Type {
+		case fileHeaderType:
+			value.Set(reflect.ValueOf(uploaded[0]))
+		case fileHeadersType:
+			value.Set(reflect.ValueOf(uploaded))
+		}
+	}
+	return nil
+}
+
+// setField converts raw to the type of value. Slices take every value, other
+// types only the first.
+func setField(value reflect.Value, raw []string) error {
+	if value.Type() == fileHeaderType || value.Type() == fileHeadersType {
+		return nil
+	}
+
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 && !value.Addr().Type().Implements(unmarshalerType) {
+		slice := reflect.MakeSlice(value.Type(), len(raw), len(raw))
+		for pos, item := range raw {
+			if err := setField(slice.Index(pos), []string{item}); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+		return nil
+	}
+
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return setField(value.Elem(), raw)
+	}
+
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(raw[0]))
+	}
+
+	text := raw[0]
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Slice:
+		value.SetBytes([]byte(text))
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.Type() == reflect.TypeOf(time.Duration(0)) {
+			parsed, err := time.ParseDuration(text)
+			if err != nil {
+				return err
+			}
+			value.SetInt(int64(parsed))
+			return nil
+		}
+
+		parsed, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("cannot bind to %s", value.Type())
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"time"
+)
+
+type bindPaging struct {
+	Page  int      `query:"page"`
+	Order []string `query:"order"`
+}
+
+type bindUser struct {
+	bindPaging
+	ID      int64                 `json:"-" xml:"-" path:"id"`
+	Name    string                `json:"name" xml:"name" form:"name"`
+	Age     int                   `json:"age" xml:"age" form:"age"`
+	Admin   *bool                 `json:"admin,omitempty" xml:"admin,omitempty" form:"admin"`
+	Since   time.Time             `json:"since" xml:"since" form:"since"`
+	Tags    []string              `json:"tags" xml:"tag" form:"tag"`
+	Avatar  *multipart.FileHeader `json:"-" xml:"-" form:"avatar"`
+	private string
+}
+
+var _ = Describe("Request binding unit tests", func() {
+
+	bind := func(contentType, url, body string, args map[string]string) (bindUser, error) {
+		r := httptest.NewRequest("POST", url, strings.NewReader(body))
+		if len(contentType) > 0 {
+			r.Header.Set("Content-Type", contentType)
+		}
+
+		var user bindUser
+		err := createRequestAdvanced(r, args).Bind(&user)
+		return user, err
+	}
+
+	When("the body is JSON", func() {
+		It("should decode it and merge path and query values", func() {
+			user, err := bind("application/json; charset=utf-8", "/users/7?page=2&order=name&order=age",
+				`{"name": "Ada", "age": 36, "admin": true, "since": "2020-01-02T03:04:05Z", "tags": ["a", "b"]}`,
+				map[string]string{"id": "7"})
+
+			Expect(err).To(BeNil())
+			Expect(user.ID).To(Equal(int64(7)))
+			Expect(user.Name).To(Equal("Ada"))
+			Expect(user.Age).To(Equal(36))
+			Expect(*user.Admin).To(BeTrue())
+			Expect(user.Since.Year()).To(Equal(2020))
+			Expect(user.Tags).To(Equal([]string{"a", "b"}))
+			Expect(user.Page).To(Equal(2))
+			Expect(user.Order).To(Equal([]string{"name", "age"}))
+		})
+
+		It("should be assumed when no content type is given", func() {
+			user, err := bind("", "/", `{"name": "Ada"}`, nil)
+			Expect(err).To(BeNil())
+			Expect(user.Name).To(Equal("Ada"))
+		})
+
+		It("should report unknown fields", func() {
+			_, err := bind("application/json", "/", `{"name": "Ada", "nickname": "ada"}`, nil)
+
+			var bindErr *BindError
+			Expect(errors.As(err, &bindErr)).To(BeTrue())
+			Expect(bindErr.Source).To(Equal("body"))
+			Expect(bindErr.Field).To(Equal("nickname"))
+			Expect(errors.Is(err, ErrUnknownField)).To(BeTrue())
+		})
+
+		It("should report values of the wrong type", func() {
+			_, err := bind("application/json", "/", `{"age": "old"}`, nil)
+
+			var bindErr *BindError
+			Expect(errors.As(err, &bindErr)).To(BeTrue())
+			Expect(bindErr.Field).To(Equal("age"))
+			Expect(bindErr.Type).To(Equal("int"))
+			Expect(err.Error()).To(Equal(`body field "age" must be a valid int`))
+		})
+
+		It("should report malformed JSON", func() {
+			_, err := bind("application/json", "/", `{"name": `, nil)
+			Expect(err).To(MatchError(ContainSubstring("body: ")))
+		})
+	})
+
+	When("the body is XML", func() {
+		It("should decode it", func() {
+			user, err := bind("application/xml", "/", `<user><name>Ada</name><age>36</age><tag>a</tag><tag>b</tag></user>`, nil)
+
+			Expect(err).To(BeNil())
+			Expect(user.Name).To(Equal("Ada"))
+			Expect(user.Tags).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should ignore elements without a matching field", func() {
+			user, err := bind("application/xml", "/", `<user><name>Ada</name><nickname>ada</nickname></user>`, nil)
+
+			Expect(err).To(BeNil())
+			Expect(user.Name).To(Equal("Ada"))
+		})
+	})
+
+	When("the body is a form", func() {
+		It("should decode url encoded forms through form tags", func() {
+			user, err := bind("application/x-www-form-urlencoded", "/", "name=Ada&age=36&admin=false&tag=a&tag=b&since=2020-01-02T03:04:05Z", nil)
+
+			Expect(err).To(BeNil())
+			Expect(user.Name).To(Equal("Ada"))
+			Expect(user.Age).To(Equal(36))
+			Expect(*user.Admin).To(BeFalse())
+			Expect(user.Tags).To(Equal([]string{"a", "b"}))
+			Expect(user.Since.Month()).To(Equal(time.January))
+		})
+
+		It("should decode multipart forms including files", func() {
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			Expect(form.WriteField("name", "Ada")).To(Succeed())
+			file, _ := form.CreateFormFile("avatar", "ada.png")
+			file.Write([]byte("image"))
+			Expect(form.Close()).To(Succeed())
+
+			user, err := bind(form.FormDataContentType(), "/", body.String(), nil)
+
+			Expect(err).To(BeNil())
+			Expect(user.Name).To(Equal("Ada"))
+			Expect(user.Avatar).ToNot(BeNil())
+			Expect(user.Avatar.Filename).To(Equal("ada.png"))
+		})
+
+		It("should remove uploads written to disk once the handler returns", func() {
+			defer func(memory int64) { multipartMemory = memory }(multipartMemory)
+			multipartMemory = 1
+
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			file, _ := form.CreateFormFile("avatar", "ada.png")
+			file.Write([]byte("image"))
+			Expect(form.Close()).To(Succeed())
+
+			var stored string
+			router := Router{}
+			router.Post("/upload", func(request Request) Response {
+				var user bindUser
+				Expect(request.Bind(&user)).To(Succeed())
+
+				upload, err := user.Avatar.Open()
+				Expect(err).To(BeNil())
+				defer upload.Close()
+
+				if onDisk, isFile := upload.(*os.File); isFile {
+					stored = onDisk.Name()
+				}
+				return request.Success("stored")
+			})
+
+			r := httptest.NewRequest("POST", "/upload", &body)
+			r.Header.Set("Content-Type", form.FormDataContentType())
+			router.ServeHTTP(httptest.NewRecorder(), r)
+
+			Expect(stored).ToNot(BeEmpty())
+			_, err := os.Stat(stored)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should report unknown fields", func() {
+			_, err := bind("application/x-www-form-urlencoded", "/", "name=Ada&nickname=ada&id=7", nil)
+
+			var bindErr *BindError
+			Expect(errors.As(err, &bindErr)).To(BeTrue())
+			Expect(bindErr.Source).To(Equal("form"))
+			Expect(bindErr.Field).To(Equal("id"))
+			Expect(errors.Is(err, ErrUnknownField)).To(BeTrue())
+		})
+
+		It("should report unknown files", func() {
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			Expect(form.WriteField("name", "Ada")).To(Succeed())
+			file, _ := form.CreateFormFile("banner", "banner.png")
+			file.Write([]byte("image"))
+			Expect(form.Close()).To(Succeed())
+
+			_, err := bind(form.FormDataContentType(), "/", body.String(), nil)
+			Expect(err).To(MatchError(`form field "banner" is not recognised`))
+		})
+
+		It("should report values which cannot be converted", func() {
+			_, err := bind("application/x-www-form-urlencoded", "/", "age=old", nil)
+			Expect(err).To(MatchError(`form field "age" must be a valid int`))
+		})
+	})
+
+	When("binding fails", func() {
+		It("should reject unsupported content types", func() {
+			_, err := bind("text/csv", "/", "a,b", nil)
+			Expect(errors.Is(err, ErrUnsupportedMediaType)).To(BeTrue())
+		})
+
+		It("should report path and query values which cannot be converted", func() {
+			_, err := bind("", "/?page=first", "", map[string]string{"id": "7"})
+			Expect(err).To(MatchError(`query field "page" must be a valid int`))
+
+			_, err = bind("", "/", "", map[string]string{"id": "seven"})
+			Expect(err).To(MatchError(`path field "id" must be a valid int64`))
+		})
+
+		It("should refuse anything but a pointer to a struct", func() {
+			r := createRequest("POST", "/", []byte(`{}`), nil)
+			var user bindUser
+			Expect(r.Bind(user)).ToNot(Succeed())
+			Expect(r.Bind(nil)).ToNot(Succeed())
+		})
+
+		It("should be turned into a response by InvalidParam", func() {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(`{"age": "old"}`))
+			request := createRequestAdvanced(r, nil)
+
+			var user bindUser
+			resp := request.InvalidParam(request.Bind(&user))
+
+			body := make(map[string]string)
+			Expect(json.Unmarshal(resp.GetResponseContent(), &body)).To(Succeed())
+			Expect(resp.GetResponseStatusCode()).To(Equal(http.StatusBadRequest))
+			Expect(body).To(HaveKeyWithValue("source", "body"))
+			Expect(body).To(HaveKeyWithValue("param", "age"))
+		})
+
+		It("should answer an unsupported content type with a 415", func() {
+			r := httptest.NewRequest("POST", "/", strings.NewReader("a,b"))
+			r.Header.Set("Content-Type", "text/csv")
+			request := createRequestAdvanced(r, nil)
+
+			var user bindUser
+			resp := request.InvalidParam(request.Bind(&user))
+			Expect(resp.GetResponseStatusCode()).To(Equal(http.StatusUnsupportedMediaType))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RouteConflictError lists every duplicate or ambiguous route found while
+// routes were being registered.
+type RouteConflictError struct {
+	Conflicts []string
+}
+
+func (e *RouteConflictError) Error() string {
+	return "router: " + strings.Join(e.Conflicts, "; ")
+}
+
+// Validate reports any routes which were registered twice, or which can match
+// exactly the same URLs as another route. Routes which only differ by a
+// trailing slash are ambiguous unless trailing slashes are strict or
+// redirected. The Serve methods call Validate and refuse to start if it fails.
+func (r *Router) Validate() error {
+	conflicts := append([]string{}, r.conflicts...)
+	if r.pathOptions().TrailingSlash == TrailingSlashIgnore {
+		conflicts = append(conflicts, r.slashConflicts...)
+	}
+
+	for _, host := range r.hosts {
+		if err, isConflict := host.router.Validate().(*RouteConflictError); isConflict {
+			for _, conflict := range err.Conflicts {
+				conflicts = append(conflicts, "host "+host.pattern+" "+conflict)
+			}
+		}
+	}
+
+	if len(conflicts) < 1 {
+		return nil
+	}
+	return &RouteConflictError{Conflicts: conflicts}
+}
+
+func (r *Router) checkConflicts(rt *route, tokens []token) {
+	if r.shapes == nil {
+		r.shapes = make(map[string]*route)
+	}
+
+	key := rt.Method + " " + shape(tokens)
+	if slashed(rt) {
+		key += "/"
+	}
+
+	existing, exists := r.shapes[key]
+	if !exists {
+		r.shapes[key] = rt
+		r.checkSlashVariant(rt, key)
+		return
+	}
+
+	if cleanPath(existing.Path) == cleanPath(rt.Path) {
+		r.conflicts = append(r.conflicts, fmt.Sprintf("duplicate route %s %s", rt.Method, rt.Path))
+		return
+	}
+	r.conflicts = append(r.conflicts, fmt.Sprintf("route %s %s is ambiguous with %s", rt.Method, rt.Path, existing.Path))
+}
+
+// checkSlashVariant records a route whose only difference from another is a
+// trailing slash. Whether that conflicts depends on the path options in force
+// when Validate is called.
+func (r *Router) checkSlashVariant(rt *route, key string) {
+	variant := strings.TrimSuffix(key, "/")
+	if variant == key {
+		variant += "/"
+	}
+
+	existing, exists := r.shapes[variant]
+	if !exists {
+		return
+	}
+
+	if cleanPath(existing.Path) == cleanPath(rt.Path) {
+		r.slashConflicts = append(r.slashConflicts, fmt.Sprintf("duplicate route %s %s", rt.Method, rt.Path))
+		return
+	}
+	r.slashConflicts = append(r.slashConflicts, fmt.Sprintf("route %s %s is ambiguous with %s", rt.Method, rt.Path, existing.Path))
+}
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Route conflict unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	When("routes do not conflict", func() {
+		It("should validate without error", func() {
+			router.Get("/user/:id", noopHandler)
+			router.Get("/user/:id<int>", noopHandler)
+			router.Get("/user/me", noopHandler)
+			router.Post("/user/:id", noopHandler)
+			router.Get("/user/:id/[:tab]", noopHandler)
+
+			Expect(router.Validate()).To(BeNil())
+		})
+	})
+
+	When("the same route is registered twice", func() {
+		It("should report a duplicate", func() {
+			router.Get("/user/:id", noopHandler)
+			router.Get("/user/:id/", noopHandler)
+
+			err := router.Validate()
+			Expect(err).To(MatchError("router: duplicate route GET /user/:id/"))
+		})
+	})
+
+	When("two routes only differ by parameter names", func() {
+		It("should report them as ambiguous", func() {
+			router.Get("/user/:id", noopHandler)
+			router.Get("/user/:name", noopHandler)
+			router.Get("/files/*path", noopHandler)
+			router.Get("/files/*rest", noopHandler)
+
+			err := router.Validate()
+			conflicts, ok := err.(*RouteConflictError)
+			Expect(ok).To(BeTrue())
+			Expect(conflicts.Conflicts).To(Equal([]string{
+				"route GET /user/:name is ambiguous with /user/:id",
+				"route GET /files/*rest is ambiguous with /files/*path",
+			}))
+		})
+	})
+
+	When("the router is served with conflicting routes", func() {
+		It("should refuse to start", func() {
+			router.Get("/user/:id", noopHandler)
+			router.Get("/user/:id", noopHandler)
+
+			Expect(router.Serve(0)).To(MatchError("router: duplicate route GET /user/:id"))
+			Expect(router.ServeContext(context.Background(), 0, ShutdownOptions{})).To(HaveOccurred())
+		})
+	})
+})
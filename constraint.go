@@ -0,0 +1,104 @@
+package router
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// constraint restricts the values a route parameter will accept, written as
+// ":id<int>" or ":name<[a-z0-9-]+>" in a route.
+type constraint struct {
+	pattern string
+	check   func(value string) bool
+}
+
+var constraints = map[string]func(value string) bool{
+	"int":   isInt,
+	"uint":  isDigits,
+	"alpha": isAlpha,
+	"alnum": isAlphaNumeric,
+	"uuid":  isUUID,
+}
+
+func (c *constraint) String() string {
+	if c == nil {
+		return ""
+	}
+	return c.pattern
+}
+
+func (c *constraint) builtin() bool {
+	_, exists := constraints[c.pattern]
+	return exists
+}
+
+func newConstraint(pattern string) (*constraint, error) {
+	if check, exists := constraints[pattern]; exists {
+		return &constraint{pattern: pattern, check: check}, nil
+	}
+
+	if len(pattern) < 1 {
+		return nil, fmt.Errorf("empty constraint")
+	}
+
+	expression, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint %q: %v", pattern, err)
+	}
+	return &constraint{pattern: pattern, check: expression.MatchString}, nil
+}
+
+// splitConstraint separates "id<int>" into its name and constraint pattern.
+func splitConstraint(param string) (string, string, bool) {
+	open := strings.Index(param, "<")
+	if open < 0 || param[len(param)-1:] != ">" {
+		return param, "", false
+	}
+	return param[:open], param[open+1 : len(param)-1], true
+}
+
+// checkParamName rejects names left behind by a malformed constraint, such as
+// "x<a" from ":x<a/b>", where the constraint was split across segments.
+func checkParamName(name string) error {
+	if len(name) < 1 {
+		return fmt.Errorf("parameter has no name")
+	}
+
+	if strings.ContainsAny(name, "<>") {
+		return fmt.Errorf("malformed constraint, constraints must be closed with > and cannot contain /")
+	}
+	return nil
+}
+
+func isInt(value string) bool {
+	if strings.HasPrefix(value, "-") {
+		value = value[1:]
+	}
+	return isDigits(value)
+}
+
+func isDigits(value string) bool {
+	if len(value) < 1 {
+		return false
+	}
+
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(value string) bool {
+	return len(value) > 0 && strings.IndexFunc(value, func(char rune) bool {
+		return (char < 'a' || char > 'z') && (char < 'A' || char > 'Z')
+	}) < 0
+}
+
+func isAlphaNumeric(value string) bool {
+	return len(value) > 0 && strings.IndexFunc(value, func(char rune) bool {
+		return (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') && (char < '0' || char > '9')
+	}) < 0
+}
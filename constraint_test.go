@@ -0,0 +1,117 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Route constraint unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	reply := func(text string) Handler {
+		return func(request Request) Response {
+			return request.Success(text + ":" + request.GetArg("id"))
+		}
+	}
+
+	When("a parameter has a built in constraint", func() {
+		It("should only match values which satisfy it", func() {
+			router.Get("/user/:id<int>", reply("int"))
+
+			Expect(serve("GET", "/user/12").Body.String()).To(Equal("int:12"))
+			Expect(serve("GET", "/user/-3").Body.String()).To(Equal("int:-3"))
+			Expect(serve("GET", "/user/abc").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should support uuid constraints", func() {
+			router.Get("/doc/:id<uuid>", reply("uuid"))
+
+			Expect(serve("GET", "/doc/3f2504e0-4f89-11d3-9a0c-0305e82c3301").Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(serve("GET", "/doc/3f2504e0").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should apply constraints to optional parameters", func() {
+			router.Get("/page/[:id<uint>]", reply("page"))
+
+			Expect(serve("GET", "/page").Body.String()).To(Equal("page:"))
+			Expect(serve("GET", "/page/4").Body.String()).To(Equal("page:4"))
+			Expect(serve("GET", "/page/four").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("a parameter has a regular expression constraint", func() {
+		It("should only match values which satisfy the whole expression", func() {
+			router.Get("/file/:id<[a-z0-9-]+>", reply("file"))
+
+			Expect(serve("GET", "/file/my-file-1").Body.String()).To(Equal("file:my-file-1"))
+			Expect(serve("GET", "/file/My_File").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("a constrained route does not match", func() {
+		It("should continue to the next candidate route", func() {
+			router.Get("/user/:id<int>", reply("numeric"))
+			router.Get("/user/:id", reply("named"))
+
+			Expect(serve("GET", "/user/12").Body.String()).To(Equal("numeric:12"))
+			Expect(serve("GET", "/user/bob").Body.String()).To(Equal("named:bob"))
+		})
+
+		It("should choose between overlapping constraints regardless of registration order", func() {
+			routes := [][]string{{"/x/:a<[0-9]+>", "regex"}, {"/x/:b<int>", "builtin"}, {"/x/:c<[a-z0-9]+>", "letters"}}
+			for _, order := range [][]int{{0, 1, 2}, {2, 1, 0}, {1, 2, 0}} {
+				router = Router{}
+				for _, pos := range order {
+					router.Get(routes[pos][0], reply(routes[pos][1]))
+				}
+
+				Expect(router.Validate()).To(BeNil())
+				Expect(serve("GET", "/x/12").Body.String()).To(Equal("builtin:"), "%v", order)
+				Expect(serve("GET", "/x/1a").Body.String()).To(Equal("letters:"), "%v", order)
+			}
+
+			router = Router{}
+			router.Get("/x/:c<[a-z0-9]+>", reply("letters"))
+			router.Get("/x/:a<[0-9]+>", reply("digits"))
+			Expect(serve("GET", "/x/12").Body.String()).To(Equal("digits:"))
+		})
+
+		It("should respond 405 only when the constraint matches under another method", func() {
+			router.Get("/user/:id<int>", reply("numeric"))
+
+			Expect(serve("POST", "/user/12").Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(serve("POST", "/user/bob").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("a route is registered with an invalid constraint", func() {
+		It("should panic at registration", func() {
+			Expect(func() {
+				router.Get("/user/:id<[a-z>", reply("broken"))
+			}).To(PanicWith(ContainSubstring(`router: route "/user/:id<[a-z>", parameter "id": invalid constraint`)))
+			Expect(router.routes).To(BeEmpty())
+		})
+
+		It("should panic when a constraint is unclosed or split across segments", func() {
+			for _, path := range []string{"/d/:x<a/b>", "/d/:x<int", "/d/:x>", "/d/:<int>", "/d/[:x", "/d/[:x<a/b>]"} {
+				Expect(func() {
+					router.Get(path, reply("broken"))
+				}).To(PanicWith(ContainSubstring("router: ")), path)
+			}
+			Expect(router.routes).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,230 @@
+package router
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSPolicy describes which cross origin requests the router will accept.
+// Origins may be "*" or contain a single wildcard, such as
+// "https://*.example.com", to accept any subdomain.
+type CORSPolicy struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// DefaultCORSPolicy is the policy a router uses until it is given another one.
+func DefaultCORSPolicy() CORSPolicy {
+	return CORSPolicy{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"*"},
+	}
+}
+
+func (r *Router) CORS(policy CORSPolicy) {
+	r.cors = &policy
+	r.corsDisabled = false
+}
+
+func (r *Router) DisableCORS() {
+	r.corsDisabled = true
+}
+
+// corsSettings finds the policy set on the router or the nearest parent which
+// has one, reporting whether CORS handling was disabled there instead.
+func (r *Router) corsSettings() (*CORSPolicy, bool) {
+	for ; r != nil; r = r.parent {
+		if r.corsDisabled {
+			return nil, true
+		}
+		if r.cors != nil {
+			return r.cors, false
+		}
+	}
+	return nil, false
+}
+
+func (r *Router) corsOff() bool {
+	_, disabled := r.corsSettings()
+	return disabled
+}
+
+func WithCORS(policy CORSPolicy) RouteOption {
+	return func(rt *route) {
+		rt.cors = &policy
+	}
+}
+
+func (rt *Router) corsPolicy(matched *route) CORSPolicy {
+	if matched != nil && matched.cors != nil {
+		return *matched.cors
+	}
+
+	if policy, _ := rt.corsSettings(); policy != nil {
+		return *policy
+	}
+	return DefaultCORSPolicy()
+}
+
+func (rt *Router) preflight(w http.ResponseWriter, r *http.Request) {
+	requestedMethod := r.Header.Get("Access-Control-Request-Method")
+	origin := r.Header.Get("Origin")
+
+	var matched *route
+	if len(requestedMethod) > 0 {
+		matched = rt.lookup(requestedMethod, r.URL.Path).route
+	}
+
+	policy := rt.corsPolicy(matched)
+	if len(origin) < 1 || len(requestedMethod) < 1 {
+		policy.inject(w, r)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	requestedHeaders := r.Header.Get("Access-Control-Request-Headers")
+	w.Header().Add("Vary", "Origin")
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	if !policy.allowsOrigin(origin) || !policy.allowsMethod(requestedMethod) || !policy.allowsHeaders(requestedHeaders) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	policy.injectOrigin(w, origin)
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(policy.methods(), ","))
+
+	if len(requestedHeaders) > 0 {
+		if policy.allowsAnyHeader() && !policy.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Headers", "*")
+		} else if policy.allowsAnyHeader() {
+			w.Header().Set("Access-Control-Allow-Headers", requestedHeaders)
+		} else {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ","))
+		}
+	}
+
+	if policy.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge/time.Second)))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// inject adds the headers an actual (non preflight) cross origin request needs.
+func (p CORSPolicy) inject(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+
+	if len(origin) < 1 {
+		if p.allowsAnyOrigin() && !p.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+		return
+	}
+
+	if !p.allowsAnyOrigin() || p.AllowCredentials {
+		w.Header().Add("Vary", "Origin")
+	}
+
+	if !p.allowsOrigin(origin) {
+		return
+	}
+
+	p.injectOrigin(w, origin)
+	if len(p.ExposedHeaders) > 0 {
+		w.Header().Set("Access-Control-Expose-Headers", strings.Join(p.ExposedHeaders, ","))
+	}
+}
+
+func (p CORSPolicy) injectOrigin(w http.ResponseWriter, origin string) {
+	if p.allowsAnyOrigin() && !p.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if p.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (p CORSPolicy) allowsAnyOrigin() bool {
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p CORSPolicy) allowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, allowed := range p.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+
+		if wildcard := strings.Index(allowed, "*"); wildcard >= 0 {
+			prefix, suffix := allowed[:wildcard], allowed[wildcard+1:]
+			if len(origin) > len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p CORSPolicy) methods() []string {
+	if len(p.AllowedMethods) < 1 {
+		return DefaultCORSPolicy().AllowedMethods
+	}
+	return p.AllowedMethods
+}
+
+func (p CORSPolicy) allowsMethod(method string) bool {
+	for _, allowed := range p.methods() {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p CORSPolicy) allowsAnyHeader() bool {
+	for _, allowed := range p.AllowedHeaders {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p CORSPolicy) allowsHeaders(requested string) bool {
+	if len(strings.TrimSpace(requested)) < 1 || p.allowsAnyHeader() {
+		return true
+	}
+
+	for _, header := range strings.Split(requested, ",") {
+		found := false
+		for _, allowed := range p.AllowedHeaders {
+			if strings.EqualFold(strings.TrimSpace(header), allowed) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("CORS unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+		router.Get("/user/:id", func(request Request) Response {
+			return request.Success("OK")
+		})
+	})
+
+	preflight := func(origin, method, headers string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("OPTIONS", "/user/12", nil)
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", method)
+		if len(headers) > 0 {
+			r.Header.Set("Access-Control-Request-Headers", headers)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	When("no policy has been given", func() {
+		It("should allow any origin", func() {
+			r := httptest.NewRequest("GET", "/user/12", nil)
+			r.Header.Set("Origin", "https://anywhere.org")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		})
+
+		It("should answer preflight requests", func() {
+			w := preflight("https://anywhere.org", "GET", "X-Custom")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(Equal("*"))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Headers")).To(Equal("*"))
+		})
+	})
+
+	When("a policy with specific origins is given", func() {
+		BeforeEach(func() {
+			router.CORS(CORSPolicy{
+				AllowedOrigins:   []string{"https://example.org", "https://*.example.com"},
+				AllowedMethods:   []string{"GET", "POST"},
+				AllowedHeaders:   []string{"Content-Type"},
+				ExposedHeaders:   []string{"X-Total"},
+				AllowCredentials: true,
+				MaxAge:           10 * time.Minute,
+			})
+		})
+
+		It("should echo an allowed origin and vary on it", func() {
+			r := httptest.NewRequest("GET", "/user/12", nil)
+			r.Header.Set("Origin", "https://api.example.com")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(Equal("https://api.example.com"))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Credentials")).To(Equal("true"))
+			Expect(w.Result().Header.Get("Access-Control-Expose-Headers")).To(Equal("X-Total"))
+			Expect(w.Result().Header.Values("Vary")).To(ContainElement("Origin"))
+		})
+
+		It("should not send CORS headers to an origin which is not allowed", func() {
+			r := httptest.NewRequest("GET", "/user/12", nil)
+			r.Header.Set("Origin", "https://example.com.evil.org")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(BeEmpty())
+		})
+
+		It("should accept a valid preflight request", func() {
+			w := preflight("https://example.org", "POST", "content-type")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(Equal("https://example.org"))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Methods")).To(Equal("GET,POST"))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Headers")).To(Equal("Content-Type"))
+			Expect(w.Result().Header.Get("Access-Control-Max-Age")).To(Equal("600"))
+		})
+
+		It("should reject a preflight request from an unknown origin", func() {
+			w := preflight("https://other.org", "GET", "")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusForbidden))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(BeEmpty())
+		})
+
+		It("should reject a preflight request for a method which is not allowed", func() {
+			w := preflight("https://example.org", "DELETE", "")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusForbidden))
+		})
+
+		It("should reject a preflight request for a header which is not allowed", func() {
+			w := preflight("https://example.org", "GET", "X-Secret")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	When("a route overrides the router policy", func() {
+		It("should use the route policy for that route", func() {
+			router.CORS(CORSPolicy{AllowedOrigins: []string{"https://example.org"}})
+			router.Get("/public", func(request Request) Response {
+				return request.Success("OK")
+			}, WithCORS(CORSPolicy{AllowedOrigins: []string{"*"}}))
+
+			r := httptest.NewRequest("OPTIONS", "/public", nil)
+			r.Header.Set("Origin", "https://other.org")
+			r.Header.Set("Access-Control-Request-Method", "GET")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		})
+	})
+
+	When("CORS is disabled", func() {
+		It("should not add any CORS headers or answer OPTIONS requests", func() {
+			router.DisableCORS()
+
+			r := httptest.NewRequest("GET", "/user/12", nil)
+			r.Header.Set("Origin", "https://example.org")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(BeEmpty())
+
+			w = preflight("https://example.org", "GET", "")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(w.Result().Header.Get("Allow")).To(Equal("GET, HEAD"))
+		})
+	})
+})
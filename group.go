@@ -0,0 +1,252 @@
+package router
+
+import (
+	"strings"
+)
+
+// Group registers routes on its router beneath a shared prefix. Middleware and
+// NotFound handlers given to a group only apply to requests under that prefix.
+type Group struct {
+	router     *Router
+	parent     *Group
+	mounted    *Router
+	source     *Group
+	alias      bool
+	prefix     string
+	middleware []Middleware
+	notFound   Handler
+}
+
+func (r *Router) Group(prefix string, setup func(g *Group)) {
+	setup(r.group(prefix, nil))
+}
+
+// Mount serves the routes of another router beneath prefix, and beneath each
+// of its roots as they are when it is mounted. Routes and groups added to the
+// mounted router afterwards are served too, and its middleware and NotFound
+// handlers continue to apply to its routes. Route names are only registered
+// the first time a router is mounted, beneath its first root.
+func (r *Router) Mount(prefix string, mounted *Router) {
+	r.mount(prefix, mounted, nil)
+}
+
+func (g *Group) Get(path string, handler Handler, options ...RouteOption) {
+	g.Route("GET", path, handler, options...)
+}
+
+func (g *Group) Post(path string, handler Handler, options ...RouteOption) {
+	g.Route("POST", path, handler, options...)
+}
+
+func (g *Group) Put(path string, handler Handler, options ...RouteOption) {
+	g.Route("PUT", path, handler, options...)
+}
+
+func (g *Group) Patch(path string, handler Handler, options ...RouteOption) {
+	g.Route("PATCH", path, handler, options...)
+}
+
+func (g *Group) Delete(path string, handler Handler, options ...RouteOption) {
+	g.Route("DELETE", path, handler, options...)
+}
+
+func (g *Group) Head(path string, handler Handler, options ...RouteOption) {
+	g.Route("HEAD", path, handler, options...)
+}
+
+func (g *Group) Options(path string, handler Handler, options ...RouteOption) {
+	g.Route("OPTIONS", path, handler, options...)
+}
+
+func (g *Group) Route(method, path string, handler Handler, options ...RouteOption) {
+	options = append([]RouteOption{inGroup(g)}, options...)
+	g.router.url(method, joinPath(g.prefix, path), handler, options...)
+}
+
+func (g *Group) Use(middleware ...Middleware) {
+	g.middleware = append(g.middleware, middleware...)
+}
+
+func (g *Group) NotFound(handler Handler) {
+	g.notFound = handler
+}
+
+func (g *Group) Group(prefix string, setup func(g *Group)) {
+	setup(g.router.group(joinPath(g.prefix, prefix), g))
+}
+
+func (g *Group) Mount(prefix string, mounted *Router) {
+	g.router.mount(joinPath(g.prefix, prefix), mounted, g)
+}
+
+func (g *Group) handlers() []Middleware {
+	switch {
+	case g.mounted != nil:
+		return g.mounted.middleware
+	case g.source != nil:
+		return flatten(g.source)
+	}
+	return g.middleware
+}
+
+func (g *Group) notFoundHandler() Handler {
+	switch {
+	case g.mounted != nil:
+		return g.mounted.notFound
+	case g.source != nil:
+		return g.source.notFoundHandler()
+	}
+	return g.notFound
+}
+
+func inGroup(g *Group) RouteOption {
+	return func(rt *route) {
+		rt.group = g
+	}
+}
+
+func (r *Router) group(prefix string, parent *Group) *Group {
+	g := &Group{router: r, parent: parent, prefix: prefix}
+	r.groups = append(r.groups, g)
+
+	for _, mount := range r.mounts {
+		mount.router.mountGroup(mount, g)
+	}
+	return g
+}
+
+// mount registers a group for each root of the mounted router. The mounted
+// router remembers these groups so anything registered on it later is passed
+// on. Only the first root of the first mount keeps route names, so that names
+// stay unique when a router is mounted more than once.
+func (r *Router) mount(prefix string, mounted *Router, parent *Group) {
+	roots := mounted.roots
+	if len(roots) < 1 {
+		roots = []string{""}
+	}
+
+	for _, root := range roots {
+		mount := r.group(joinPath(prefix, root), parent)
+		mount.mounted, mount.alias = mounted, len(mounted.mounts) > 0
+		mounted.mounts = append(mounted.mounts, mount)
+
+		for _, existing := range mounted.groups {
+			r.mountGroup(mount, existing)
+		}
+
+		for _, existing := range mounted.routes {
+			r.mountRoute(mount, existing)
+		}
+	}
+}
+
+func (r *Router) mountGroup(mount *Group, existing *Group) {
+	copied := r.group(joinPath(mount.prefix, existing.prefix), mount)
+	copied.source = existing
+}
+
+// mountRoute copies a route into a mount. The copy belongs to the mounted
+// copy of the route's group, so group middleware is read when requests are
+// served rather than when the route is copied.
+func (r *Router) mountRoute(mount *Group, existing *route) {
+	options := []RouteOption{inGroup(r.mountedGroup(mount, existing.group)), WithMiddleware(existing.middleware...)}
+
+	if len(existing.Name) > 0 && !mount.alias {
+		options = append(options, WithName(existing.Name))
+	}
+
+	if existing.httpHandler != nil {
+		options = append(options, wrapping(existing.httpHandler))
+	}
+
+	if existing.doc != nil {
+		options = append(options, WithDoc(*existing.doc))
+	}
+
+	if existing.hidden {
+		options = append(options, hidden())
+	}
+
+	if existing.cors != nil {
+		options = append(options, WithCORS(*existing.cors))
+	} else if mount.mounted.cors != nil {
+		options = append(options, WithCORS(*mount.mounted.cors))
+	}
+
+	r.url(existing.Method, joinPath(mount.prefix, existing.Path), existing.Handler, options...)
+}
+
+func (r *Router) mountedGroup(mount *Group, existing *Group) *Group {
+	if existing == nil {
+		return mount
+	}
+
+	for _, g := range r.groups {
+		if g.parent == mount && g.source == existing {
+			return g
+		}
+	}
+	return mount
+}
+
+// notFoundGroup finds the most deeply nested group with a NotFound handler
+// whose prefix contains the URL.
+func (rt *Router) notFoundGroup(url string) *Group {
+	var found *Group
+	depth := -1
+
+	url, underRoot := rt.relativePath(url)
+	if !underRoot {
+		return nil
+	}
+
+	for _, g := range rt.groups {
+		if g.notFoundHandler() == nil {
+			continue
+		}
+
+		if matches, length := prefixMatches(g.prefix, url); matches && length > depth {
+			found, depth = g, length
+		}
+	}
+	return found
+}
+
+// flatten collects the middleware of a group and its parents, outermost first.
+func flatten(g *Group) []Middleware {
+	middleware := make([]Middleware, 0)
+	for ; g != nil; g = g.parent {
+		middleware = append(append([]Middleware{}, g.handlers()...), middleware...)
+	}
+	return middleware
+}
+
+func prefixMatches(prefix, url string) (bool, int) {
+	prefix, url = strings.Trim(prefix, "/"), strings.Trim(url, "/")
+	if len(prefix) < 1 {
+		return true, 0
+	}
+
+	prefixBits, urlBits := strings.Split(prefix, "/"), strings.Split(url, "/")
+	if len(urlBits) < len(prefixBits) {
+		return false, 0
+	}
+
+	for pos, bit := range prefixBits {
+		if len(bit) > 1 && (bit[0:1] == ":" || bit[0:2] == "[:") {
+			continue
+		}
+
+		if bit != urlBits[pos] {
+			return false, 0
+		}
+	}
+	return true, len(prefixBits)
+}
+
+func joinPath(prefix, path string) string {
+	if len(strings.TrimLeft(path, "/")) < 1 && len(strings.TrimRight(prefix, "/")) > 0 {
+		return strings.TrimRight(prefix, "/")
+	}
+	return strings.TrimRight(prefix, "/") + "/" + strings.TrimLeft(path, "/")
+}
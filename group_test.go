@@ -0,0 +1,194 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Group unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	tag := func(name string) Middleware {
+		return func(handler Handler) Handler {
+			return func(request Request) Response {
+				request.Response(name + ">")
+				return handler(request)
+			}
+		}
+	}
+
+	When("routes are registered in a group", func() {
+		It("should prefix each route", func() {
+			router.Group("/admin", func(g *Group) {
+				g.Get("/users/:id", func(request Request) Response {
+					return request.Success(request.GetArg("id"))
+				})
+			})
+
+			w := serve("GET", "/admin/users/12")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("12"))
+
+			Expect(serve("GET", "/users/12").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should only apply group middleware to routes in the group", func() {
+			router.Use(tag("global"))
+			router.Get("/", func(request Request) Response {
+				return request.Response("root")
+			})
+			router.Group("/admin", func(g *Group) {
+				g.Use(tag("admin"))
+				g.Group("/reports", func(g *Group) {
+					g.Use(tag("reports"))
+					g.Get("/", func(request Request) Response {
+						return request.Response("handler")
+					}, WithMiddleware(tag("route")))
+				})
+			})
+
+			Expect(serve("GET", "/admin/reports").Body.String()).To(Equal("global>admin>reports>route>handler"))
+			Expect(serve("GET", "/").Body.String()).To(Equal("global>root"))
+		})
+
+		It("should serve the group NotFound handler for unknown URLs under the prefix", func() {
+			router.NotFound(func(request Request) Response {
+				return request.Error(http.StatusNotFound, "router not found")
+			})
+			router.Group("/admin", func(g *Group) {
+				g.Use(tag("admin"))
+				g.NotFound(func(request Request) Response {
+					return request.Error(http.StatusNotFound, "admin not found")
+				})
+			})
+
+			Expect(serve("GET", "/admin/missing").Body.String()).To(Equal("admin>admin not found"))
+			Expect(serve("GET", "/administrator").Body.String()).To(Equal("router not found"))
+			Expect(serve("GET", "/missing").Body.String()).To(Equal("router not found"))
+		})
+	})
+
+	When("a router is mounted", func() {
+		var mounted *Router
+		BeforeEach(func() {
+			mounted = &Router{}
+			mounted.Use(tag("mounted"))
+			mounted.Get("/items/:id", func(request Request) Response {
+				return request.Response("item " + request.GetArg("id"))
+			})
+			mounted.NotFound(func(request Request) Response {
+				return request.Error(http.StatusNotFound, "v2 not found")
+			})
+		})
+
+		It("should serve its routes beneath the prefix with its own middleware", func() {
+			router.Use(tag("global"))
+			router.Mount("/v2", mounted)
+
+			w := serve("GET", "/v2/items/7")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("global>mounted>item 7"))
+
+			Expect(serve("GET", "/items/7").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should use the mounted router's NotFound handler beneath the prefix", func() {
+			router.Mount("/v2", mounted)
+
+			w := serve("GET", "/v2/missing")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(w.Body.String()).To(Equal("mounted>v2 not found"))
+		})
+
+		It("should serve routes and groups added to the mounted router afterwards", func() {
+			router.Mount("/v2", mounted)
+			mounted.Get("/late", func(request Request) Response {
+				return request.Response("late")
+			})
+			mounted.Group("/orders", func(g *Group) {
+				g.NotFound(func(request Request) Response {
+					return request.Error(http.StatusNotFound, "no such order")
+				})
+			})
+
+			Expect(serve("GET", "/v2/late").Body.String()).To(Equal("mounted>late"))
+			Expect(serve("GET", "/v2/orders/9").Body.String()).To(Equal("mounted>no such order"))
+		})
+
+		It("should pass late routes through every level of nested mounts", func() {
+			middle := &Router{}
+			middle.Mount("/v2", mounted)
+			router.Mount("/api", middle)
+			mounted.Get("/late", func(request Request) Response {
+				return request.Response("late")
+			})
+
+			Expect(serve("GET", "/api/v2/late").Body.String()).To(Equal("mounted>late"))
+		})
+
+		It("should mount the router beneath each of its roots", func() {
+			mounted.Root("/a", "/b")
+			mounted.Get("/named", noopHandler, WithName("named"))
+			router.Mount("/v2", mounted)
+
+			Expect(serve("GET", "/v2/a/items/7").Body.String()).To(Equal("mounted>item 7"))
+			Expect(serve("GET", "/v2/b/items/7").Body.String()).To(Equal("mounted>item 7"))
+			Expect(router.Validate()).To(Succeed())
+
+			url, err := router.URL("named", nil)
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/v2/a/named"))
+		})
+
+		It("should apply group middleware added to the mounted router afterwards", func() {
+			var orders *Group
+			mounted.Group("/orders", func(g *Group) {
+				orders = g
+				g.Group("/open", func(g *Group) {
+					g.Get("/:id", func(request Request) Response {
+						return request.Response("order " + request.GetArg("id"))
+					})
+				})
+			})
+			router.Mount("/v2", mounted)
+			orders.Use(tag("orders"))
+
+			Expect(serve("GET", "/v2/orders/open/9").Body.String()).To(Equal("mounted>orders>order 9"))
+		})
+
+		It("should allow a router with named routes to be mounted more than once", func() {
+			mounted.Get("/named", noopHandler, WithName("named"))
+			router.Mount("/one", mounted)
+			router.Mount("/two", mounted)
+
+			Expect(router.Validate()).To(Succeed())
+			Expect(serve("GET", "/one/items/7").Body.String()).To(Equal("mounted>item 7"))
+			Expect(serve("GET", "/two/items/7").Body.String()).To(Equal("mounted>item 7"))
+
+			url, err := router.URL("named", nil)
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/one/named"))
+		})
+
+		It("should support mounting inside a group", func() {
+			router.Group("/api", func(g *Group) {
+				g.Mount("/v2", mounted)
+			})
+
+			Expect(serve("GET", "/api/v2/items/7").Body.String()).To(Equal("mounted>item 7"))
+		})
+	})
+})
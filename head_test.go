@@ -0,0 +1,90 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("HEAD and OPTIONS unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+		router.Get("/user/:id", func(request Request) Response {
+			request.SetHeader("X-User", request.GetArg("id"))
+			return request.Success("user " + request.GetArg("id"))
+		})
+	})
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, url, nil)
+		r.Header.Set("Origin", "https://example.org")
+		r.Header.Set("Access-Control-Request-Method", "GET")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	When("a HEAD request is made", func() {
+		It("should fall back to the GET handler without a body", func() {
+			w := serve("HEAD", "/user/12")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("X-User")).To(Equal("12"))
+			Expect(w.Result().Header.Get("Content-Length")).To(Equal("7"))
+			Expect(w.Body.Len()).To(BeZero())
+		})
+
+		It("should prefer a registered HEAD handler", func() {
+			router.Head("/user/:id", func(request Request) Response {
+				request.SetHeader("X-Head", "explicit")
+				return request.Success("")
+			})
+			w := serve("HEAD", "/user/12")
+
+			Expect(w.Result().Header.Get("X-Head")).To(Equal("explicit"))
+			Expect(w.Result().Header.Get("X-User")).To(BeEmpty())
+		})
+
+		It("should respond 405 when the URL has no GET route", func() {
+			router.Post("/items", noopHandler)
+			w := serve("HEAD", "/items")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(w.Result().Header.Get("Allow")).To(Equal("OPTIONS, POST"))
+			Expect(w.Body.Len()).To(BeZero())
+		})
+	})
+
+	When("an OPTIONS request is made", func() {
+		It("should answer the CORS preflight automatically", func() {
+			w := serve("OPTIONS", "/user/12")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Methods")).ToNot(BeEmpty())
+		})
+
+		It("should use the route's own OPTIONS handler instead of the preflight", func() {
+			router.Options("/user/:id", func(request Request) Response {
+				request.SetHeader("Allow", "GET, OPTIONS")
+				return request.Response(http.StatusNoContent, "")
+			})
+			w := serve("OPTIONS", "/user/12")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusNoContent))
+			Expect(w.Result().Header.Get("Allow")).To(Equal("GET, OPTIONS"))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Origin")).To(Equal("*"))
+			Expect(w.Result().Header.Get("Access-Control-Allow-Methods")).To(BeEmpty())
+		})
+
+		It("should only override the preflight for the matching route", func() {
+			router.Group("/admin", func(g *Group) {
+				g.Options("/reports", noopHandler)
+			})
+
+			Expect(serve("OPTIONS", "/user/12").Result().Header.Get("Access-Control-Allow-Methods")).ToNot(BeEmpty())
+		})
+	})
+})
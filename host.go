@@ -0,0 +1,169 @@
+package router
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+type hostRouter struct {
+	pattern string
+	labels  []token
+	router  *Router
+}
+
+// Host returns a router which only serves requests for the given host. Labels
+// starting with a colon capture part of the host, so ":tenant.example.com"
+// makes the "tenant" argument available through GetArg. A host router runs
+// inside its parent's middleware and uses the parent's logger, panic handler,
+// CORS policy, path options and NotFound handlers until it is given its own.
+// The parent serves any other host.
+func (r *Router) Host(pattern string) *Router {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	for _, existing := range r.hosts {
+		if existing.pattern == pattern {
+			return existing.router
+		}
+	}
+
+	labels, err := tokeniseHost(pattern)
+	if err != nil {
+		panic("router: " + err.Error())
+	}
+
+	for _, existing := range r.hosts {
+		if hostShape(existing.labels) == hostShape(labels) {
+			r.conflicts = append(r.conflicts, fmt.Sprintf("host %s is ambiguous with %s", pattern, existing.pattern))
+		}
+	}
+
+	host := &hostRouter{pattern: pattern, labels: labels, router: &Router{parent: r}}
+	r.hosts = append(r.hosts, host)
+	return host.router
+}
+
+// matchHost finds the host router for a request, preferring the pattern with
+// the most literal labels when several match.
+func (r *Router) matchHost(host string) (*Router, map[string]string) {
+	var found *hostRouter
+	var args map[string]string
+	literals := -1
+
+	labels := strings.Split(hostname(host), ".")
+	for _, candidate := range r.hosts {
+		captured, matches := candidate.match(labels)
+		if !matches {
+			continue
+		}
+
+		if count := candidate.literals(); count > literals {
+			found, args, literals = candidate, captured, count
+		}
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+	return found.router, args
+}
+
+func (h *hostRouter) match(labels []string) (map[string]string, bool) {
+	if len(labels) != len(h.labels) {
+		return nil, false
+	}
+
+	var args map[string]string
+	for pos, label := range h.labels {
+		if label.kind == staticNode {
+			if label.text != labels[pos] {
+				return nil, false
+			}
+			continue
+		}
+
+		if len(labels[pos]) < 1 || (label.constraint != nil && !label.constraint.check(labels[pos])) {
+			return nil, false
+		}
+
+		if args == nil {
+			args = make(map[string]string)
+		}
+		args[label.text] = labels[pos]
+	}
+	return args, true
+}
+
+func (h *hostRouter) literals() int {
+	count := 0
+	for _, label := range h.labels {
+		if label.kind == staticNode {
+			count++
+		}
+	}
+	return count
+}
+
+func tokeniseHost(pattern string) ([]token, error) {
+	labels := make([]token, 0)
+	for _, label := range strings.Split(pattern, ".") {
+		if len(label) < 1 {
+			return nil, fmt.Errorf("host %q contains an empty label", pattern)
+		}
+
+		if label[0:1] != ":" {
+			labels = append(labels, token{kind: staticNode, text: label})
+			continue
+		}
+
+		param := token{kind: paramNode, text: label[1:]}
+		if name, expression, constrained := splitConstraint(param.text); constrained {
+			var err error
+			if param.constraint, err = newConstraint(expression); err != nil {
+				return nil, fmt.Errorf("host %q, parameter %q: %v", pattern, name, err)
+			}
+			param.text = name
+		}
+
+		if err := checkParamName(param.text); err != nil {
+			return nil, fmt.Errorf("host %q, parameter %q: %v", pattern, label, err)
+		}
+		labels = append(labels, param)
+	}
+	return labels, nil
+}
+
+func hostShape(labels []token) string {
+	shape := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if label.kind == staticNode {
+			shape = append(shape, label.text)
+		} else {
+			shape = append(shape, ":<"+label.constraint.String()+">")
+		}
+	}
+	return strings.Join(shape, ".")
+}
+
+func hostname(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// mergeArgs combines host and path arguments, with path arguments taking
+// precedence when both use the same name.
+func mergeArgs(hostArgs, pathArgs map[string]string) map[string]string {
+	if len(hostArgs) < 1 {
+		return pathArgs
+	}
+
+	args := make(map[string]string, len(hostArgs)+len(pathArgs))
+	for name, value := range hostArgs {
+		args[name] = value
+	}
+	for name, value := range pathArgs {
+		args[name] = value
+	}
+	return args
+}
@@ -0,0 +1,163 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Host routing unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+		router.Get("/", func(request Request) Response {
+			return request.Success("default")
+		})
+
+		router.Host("api.example.com").Get("/", func(request Request) Response {
+			return request.Success("api")
+		})
+
+		router.Host(":tenant.example.com").Get("/users/:id", func(request Request) Response {
+			return request.Success(request.GetArg("tenant") + " " + request.GetArg("id"))
+		})
+	})
+
+	serve := func(host, url string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", url, nil)
+		r.Host = host
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	When("a request is for a registered host", func() {
+		It("should be served by the host router", func() {
+			Expect(serve("api.example.com", "/").Body.String()).To(Equal("api"))
+		})
+
+		It("should ignore the port and letter case of the host", func() {
+			Expect(serve("API.Example.com:8080", "/").Body.String()).To(Equal("api"))
+		})
+
+		It("should expose host parameters alongside path parameters", func() {
+			w := serve("acme.example.com", "/users/7")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("acme 7"))
+		})
+
+		It("should prefer a literal host over a pattern", func() {
+			router.Host("api.example.com").Get("/users/:id", func(request Request) Response {
+				return request.Success("literal")
+			})
+
+			Expect(serve("api.example.com", "/users/7").Body.String()).To(Equal("literal"))
+		})
+
+		It("should respond 404 from the host router rather than falling back", func() {
+			Expect(serve("acme.example.com", "/").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should apply constraints to host parameters", func() {
+			router.Host(":region<alpha>.eu.example.com").Get("/", func(request Request) Response {
+				return request.Success(request.GetArg("region"))
+			})
+
+			Expect(serve("west.eu.example.com", "/").Body.String()).To(Equal("west"))
+			Expect(serve("west2.eu.example.com", "/").Body.String()).To(Equal("default"))
+		})
+	})
+
+	When("a request is for any other host", func() {
+		It("should be served by the parent router", func() {
+			Expect(serve("example.com", "/").Body.String()).To(Equal("default"))
+			Expect(serve("a.b.example.com", "/").Body.String()).To(Equal("default"))
+		})
+	})
+
+	When("the parent router is configured", func() {
+		It("should run the parent's middleware around host routes", func() {
+			router.Use(func(next Handler) Handler {
+				return func(request Request) Response {
+					request.SetHeader("X-Parent", "yes")
+					return next(request)
+				}
+			})
+
+			w := serve("acme.example.com", "/users/7")
+			Expect(w.Body.String()).To(Equal("acme 7"))
+			Expect(w.Header().Get("X-Parent")).To(Equal("yes"))
+			Expect(router.Routes()[2].Middleware).To(Equal(1))
+		})
+
+		It("should use the parent's logger and panic handler", func() {
+			log := &recordingLog{}
+			router.Logger(log)
+			router.PanicHandler(func(request Request, recovered interface{}) Response {
+				return request.Error("handled")
+			})
+			router.Host("api.example.com").Get("/panic", func(request Request) Response {
+				panic("host failure")
+			})
+
+			w := serve("api.example.com", "/panic")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(Equal("handled"))
+			Expect(log.errors).To(HaveLen(1))
+		})
+
+		It("should use the parent's NotFound handler and path options until given its own", func() {
+			router.NotFound(func(request Request) Response {
+				return request.Error("parent missing")
+			})
+			router.Paths(PathOptions{CleanPath: true})
+
+			Expect(serve("api.example.com", "/missing").Body.String()).To(Equal("parent missing"))
+			Expect(serve("api.example.com", "/users//7").Result().StatusCode).To(Equal(http.StatusMovedPermanently))
+
+			router.Host("api.example.com").NotFound(func(request Request) Response {
+				return request.Error("host missing")
+			})
+			Expect(serve("api.example.com", "/missing").Body.String()).To(Equal("host missing"))
+		})
+
+		It("should use the parent's CORS settings until given its own", func() {
+			router.DisableCORS()
+			Expect(serve("api.example.com", "/").Header().Get("Access-Control-Allow-Origin")).To(BeEmpty())
+
+			router.Host("api.example.com").CORS(DefaultCORSPolicy())
+			Expect(serve("api.example.com", "/").Header().Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		})
+	})
+
+	When("host routers are registered", func() {
+		It("should return the same router for the same host", func() {
+			Expect(router.Host("API.example.com")).To(BeIdenticalTo(router.Host("api.example.com")))
+		})
+
+		It("should report ambiguous hosts and conflicting host routes", func() {
+			router.Host(":name.example.com")
+			router.Host("api.example.com").Get("/", noopHandler)
+
+			err := router.Validate()
+			Expect(err).To(MatchError(ContainSubstring("host :name.example.com is ambiguous with :tenant.example.com")))
+			Expect(err).To(MatchError(ContainSubstring("host api.example.com duplicate route GET /")))
+		})
+
+		It("should list host routes with their host", func() {
+			routes := router.Routes()
+
+			Expect(routes).To(HaveLen(3))
+			Expect(routes[0].Host).To(BeEmpty())
+			Expect(routes[2].Host).To(Equal(":tenant.example.com"))
+			Expect(routes[2].Pattern).To(Equal("/users/:id"))
+		})
+
+		It("should panic when a host pattern is invalid", func() {
+			Expect(func() { router.Host("api..example.com") }).To(PanicWith(ContainSubstring("router: ")))
+		})
+	})
+})
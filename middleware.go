@@ -0,0 +1,45 @@
+package router
+
+type Middleware func(handler Handler) Handler
+
+// Use adds middleware which wraps every handler the router serves, including
+// the NotFound and MethodNotAllowed handlers. Middleware runs in the order it
+// was added, before any group middleware and any middleware given to an
+// individual route.
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+func WithMiddleware(middleware ...Middleware) RouteOption {
+	return func(rt *route) {
+		rt.middleware = append(rt.middleware, middleware...)
+	}
+}
+
+func (rt *Router) wrap(handler Handler, matched *route, scope *Group) Handler {
+	if matched != nil {
+		handler = chain(handler, matched.middleware)
+		scope = matched.group
+	}
+
+	for ; scope != nil; scope = scope.parent {
+		handler = chain(handler, scope.handlers())
+	}
+	return chain(handler, rt.inheritedMiddleware())
+}
+
+// inheritedMiddleware lists the middleware of every parent router, outermost
+// first, followed by the router's own.
+func (r *Router) inheritedMiddleware() []Middleware {
+	if r.parent == nil {
+		return r.middleware
+	}
+	return append(append([]Middleware{}, r.parent.inheritedMiddleware()...), r.middleware...)
+}
+
+func chain(handler Handler, middleware []Middleware) Handler {
+	for pos := len(middleware) - 1; pos >= 0; pos-- {
+		handler = middleware[pos](handler)
+	}
+	return handler
+}
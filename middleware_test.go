@@ -0,0 +1,125 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Middleware unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	tag := func(name string) Middleware {
+		return func(handler Handler) Handler {
+			return func(request Request) Response {
+				request.Response(name + ">")
+				request = handler(request)
+				return request.Response("<" + name)
+			}
+		}
+	}
+
+	When("global and route middleware are both given", func() {
+		It("should run global middleware first, then route middleware, then the handler", func() {
+			router.Use(tag("one"), tag("two"))
+			router.Get("/", func(request Request) Response {
+				return request.Response("handler")
+			}, WithMiddleware(tag("three")))
+
+			r := httptest.NewRequest("GET", "/", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("one>two>three>handler<three<two<one"))
+		})
+
+		It("should not run route middleware for other routes", func() {
+			router.Get("/", func(request Request) Response {
+				return request.Response("handler")
+			}, WithMiddleware(tag("three")))
+			router.Get("/other", func(request Request) Response {
+				return request.Response("other")
+			})
+
+			r := httptest.NewRequest("GET", "/other", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Body.String()).To(Equal("other"))
+		})
+	})
+
+	When("middleware is registered after the routes", func() {
+		It("should still apply global middleware", func() {
+			router.Get("/", func(request Request) Response {
+				return request.Response("handler")
+			})
+			router.Use(tag("late"))
+
+			r := httptest.NewRequest("GET", "/", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Body.String()).To(Equal("late>handler<late"))
+		})
+	})
+
+	When("no route matches", func() {
+		It("should wrap the NotFound handler in global middleware", func() {
+			router.Use(tag("global"))
+			router.NotFound(func(request Request) Response {
+				return request.Error(http.StatusNotFound, "missing")
+			})
+
+			r := httptest.NewRequest("GET", "/missing", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(w.Body.String()).To(Equal("global>missing<global"))
+		})
+
+		It("should wrap the default 404 response in global middleware", func() {
+			router.Use(func(handler Handler) Handler {
+				return func(request Request) Response {
+					request.SetHeader("X-Middleware", "ran")
+					return handler(request)
+				}
+			})
+
+			r := httptest.NewRequest("GET", "/missing", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(w.Result().Header.Get("X-Middleware")).To(Equal("ran"))
+			Expect(w.Body.String()).To(Equal("No provider could be found"))
+		})
+	})
+
+	When("middleware returns early", func() {
+		It("should not call the handler", func() {
+			router.Use(func(handler Handler) Handler {
+				return func(request Request) Response {
+					return request.Error(http.StatusUnauthorized, "denied")
+				}
+			})
+			router.Get("/", func(request Request) Response {
+				return request.Success("should not be seen")
+			})
+
+			r := httptest.NewRequest("GET", "/", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(w.Body.String()).To(Equal("denied"))
+		})
+	})
+})
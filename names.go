@@ -0,0 +1,92 @@
+package router
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+func WithName(name string) RouteOption {
+	return func(rt *route) {
+		rt.Name = name
+	}
+}
+
+// URL builds the path of a named route, filling in its parameters. Optional
+// parameters may be left out as long as nothing after them is supplied.
+func (r *Router) URL(name string, params map[string]string) (string, error) {
+	rt, exists := r.names[name]
+	if !exists {
+		return "", fmt.Errorf("router: no route is named %q", name)
+	}
+
+	var path strings.Builder
+	if root := strings.Trim(r.root(), "/"); len(root) > 0 {
+		path.WriteString("/" + root)
+	}
+	omitted := ""
+
+	for _, t := range rt.tokens {
+		value := params[t.text]
+
+		if t.kind == optionalNode && len(value) < 1 {
+			if len(omitted) < 1 {
+				omitted = t.text
+			}
+			continue
+		}
+
+		if len(omitted) > 0 {
+			return "", fmt.Errorf("router: route %q cannot be built without optional parameter %q", name, omitted)
+		}
+
+		switch t.kind {
+		case staticNode:
+			path.WriteString(t.text)
+			continue
+		case paramNode:
+			if len(value) < 1 {
+				return "", fmt.Errorf("router: route %q is missing parameter %q", name, t.text)
+			}
+		}
+
+		if t.constraint != nil && !t.constraint.check(value) {
+			return "", fmt.Errorf("router: parameter %q of route %q does not satisfy <%s>", t.text, name, t.constraint.pattern)
+		}
+
+		if t.kind == wildcardNode {
+			path.WriteString("/" + escapeSegments(value))
+		} else {
+			path.WriteString("/" + url.PathEscape(value))
+		}
+	}
+
+	if path.Len() < 1 {
+		return "/", nil
+	}
+	return path.String(), nil
+}
+
+func (r *Router) registerName(rt *route) {
+	if len(rt.Name) < 1 {
+		return
+	}
+
+	if r.names == nil {
+		r.names = make(map[string]*route)
+	}
+
+	if existing, exists := r.names[rt.Name]; exists {
+		r.conflicts = append(r.conflicts, fmt.Sprintf("route name %q is used by both %s %s and %s %s", rt.Name, existing.Method, existing.Path, rt.Method, rt.Path))
+		return
+	}
+	r.names[rt.Name] = rt
+}
+
+func escapeSegments(path string) string {
+	segments := strings.Split(path, "/")
+	for pos, segment := range segments {
+		segments[pos] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,101 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Named route unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+		router.Get("/", noopHandler, WithName("home"))
+		router.Get("/users/:id<int>/posts/[:slug]", noopHandler, WithName("post"))
+		router.Get("/archive/[:year]/[:month]", noopHandler, WithName("archive"))
+		router.Get("/files/*path", noopHandler, WithName("file"))
+	})
+
+	When("building the URL of a named route", func() {
+		It("should fill in the parameters", func() {
+			url, err := router.URL("post", map[string]string{"id": "12", "slug": "hello world"})
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/users/12/posts/hello%20world"))
+		})
+
+		It("should leave out trailing optional parameters", func() {
+			url, err := router.URL("post", map[string]string{"id": "12"})
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/users/12/posts"))
+
+			url, err = router.URL("archive", nil)
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/archive"))
+		})
+
+		It("should keep the slashes of a wildcard value", func() {
+			url, err := router.URL("file", map[string]string{"path": "docs/read me.txt"})
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/files/docs/read%20me.txt"))
+		})
+
+		It("should respect the router root", func() {
+			router.Root("/api/")
+
+			url, err := router.URL("post", map[string]string{"id": "3"})
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/api/users/3/posts"))
+
+			url, err = router.URL("home", nil)
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/api/"))
+		})
+
+		It("should return the root path for the root route", func() {
+			url, err := router.URL("home", nil)
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/"))
+		})
+
+		It("should include the prefix of groups and mounted routers", func() {
+			mounted := &Router{}
+			mounted.Get("/items/:id", noopHandler, WithName("item"))
+			router.Group("/shop", func(g *Group) {
+				g.Mount("/v2", mounted)
+			})
+
+			url, err := router.URL("item", map[string]string{"id": "7"})
+			Expect(err).To(BeNil())
+			Expect(url).To(Equal("/shop/v2/items/7"))
+		})
+	})
+
+	When("the URL cannot be built", func() {
+		It("should fail for an unknown name", func() {
+			_, err := router.URL("missing", nil)
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should fail when a mandatory parameter is missing", func() {
+			_, err := router.URL("post", map[string]string{"slug": "hello"})
+			Expect(err).To(MatchError(ContainSubstring(`missing parameter "id"`)))
+		})
+
+		It("should fail when a parameter does not satisfy its constraint", func() {
+			_, err := router.URL("post", map[string]string{"id": "abc"})
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should fail when an optional parameter is skipped before a later one", func() {
+			_, err := router.URL("archive", map[string]string{"month": "06"})
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
+	When("two routes share a name", func() {
+		It("should be reported by Validate", func() {
+			router.Post("/users", noopHandler, WithName("home"))
+			Expect(router.Validate()).To(MatchError(ContainSubstring(`route name "home"`)))
+		})
+	})
+})
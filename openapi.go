@@ -0,0 +1,301 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RouteDoc describes a route for the OpenAPI document. Request and the values
+// of Responses are example values, such as User{} or []User{}, whose Go types
+// are turned into JSON schemas. A nil response value documents an empty body.
+type RouteDoc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Request     interface{}
+	Responses   map[int]interface{}
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type openAPISchema map[string]interface{}
+
+func WithDoc(doc RouteDoc) RouteOption {
+	return func(rt *route) {
+		rt.doc = &doc
+	}
+}
+
+// OpenAPI generates an OpenAPI 3 document, encoded as JSON, describing every
+// route registered so far. Path parameters are taken from the route patterns,
+// with each optional parameter producing an extra path.
+func (r *Router) OpenAPI(info OpenAPIInfo) ([]byte, error) {
+	schemas := make(map[string]openAPISchema)
+	paths := make(map[string]map[string]interface{})
+
+	for _, rt := range r.routes {
+		if rt.hidden {
+			continue
+		}
+
+		for _, variant := range openAPIPaths(rt.tokens) {
+			path := cleanPath(joinPath(r.root(), variant.path))
+
+			if paths[path] == nil {
+				paths[path] = make(map[string]interface{})
+			}
+			paths[path][strings.ToLower(rt.Method)] = openAPIOperation(rt, variant.params, schemas)
+		}
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    info,
+		"paths":   paths,
+	}
+	if len(schemas) > 0 {
+		document["components"] = map[string]interface{}{"schemas": schemas}
+	}
+	return json.Marshal(document)
+}
+
+// ServeOpenAPI registers a GET route at path which serves the OpenAPI
+// document. The route itself is left out of the document.
+func (r *Router) ServeOpenAPI(path string, info OpenAPIInfo) {
+	r.Get(path, func(request Request) Response {
+		document, err := r.OpenAPI(info)
+		if err != nil {
+			return request.Error(http.StatusInternalServerError, err.Error())
+		}
+
+		request.SetHeader("Content-Type", "application/json")
+		return request.Success(document)
+	}, hidden())
+}
+
+func hidden() RouteOption {
+	return func(rt *route) {
+		rt.hidden = true
+	}
+}
+
+type openAPIPath struct {
+	path   string
+	params []token
+}
+
+// openAPIPaths turns route tokens into OpenAPI paths. A route with optional
+// parameters produces one path without them and one more for each of them.
+func openAPIPaths(tokens []token) []openAPIPath {
+	paths := make([]openAPIPath, 0, 1)
+	path, params := "", make([]token, 0)
+
+	for _, t := range tokens {
+		switch t.kind {
+		case staticNode:
+			path += t.text
+			continue
+		case optionalNode:
+			paths = append(paths, openAPIPath{path: cleanPath(path), params: append([]token{}, params...)})
+		}
+
+		path += "/{" + t.text + "}"
+		params = append(params, t)
+	}
+
+	return append(paths, openAPIPath{path: cleanPath(path), params: params})
+}
+
+func openAPIOperation(rt *route, params []token, schemas map[string]openAPISchema) map[string]interface{} {
+	operation := make(map[string]interface{})
+	doc := rt.doc
+	if doc == nil {
+		doc = &RouteDoc{}
+	}
+
+	if len(rt.Name) > 0 {
+		operation["operationId"] = rt.Name
+	}
+	if len(doc.Summary) > 0 {
+		operation["summary"] = doc.Summary
+	}
+	if len(doc.Description) > 0 {
+		operation["description"] = doc.Description
+	}
+	if len(doc.Tags) > 0 {
+		operation["tags"] = doc.Tags
+	}
+
+	if len(params) > 0 {
+		parameters := make([]map[string]interface{}, 0, len(params))
+		for _, param := range params {
+			parameter := map[string]interface{}{
+				"name":     param.text,
+				"in":       "path",
+				"required": true,
+				"schema":   constraintSchema(param.constraint),
+			}
+			if param.kind == wildcardNode {
+				parameter["description"] = "The remainder of the path, which may contain slashes"
+			}
+			parameters = append(parameters, parameter)
+		}
+		operation["parameters"] = parameters
+	}
+
+	if doc.Request != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  openAPIContent(doc.Request, schemas),
+		}
+	}
+
+	responses := make(map[string]interface{})
+	for code, body := range doc.Responses {
+		response := map[string]interface{}{"description": http.StatusText(code)}
+		if body != nil {
+			response["content"] = openAPIContent(body, schemas)
+		}
+		responses[strconv.Itoa(code)] = response
+	}
+	if len(responses) < 1 {
+		responses["200"] = map[string]interface{}{"description": http.StatusText(http.StatusOK)}
+	}
+	operation["responses"] = responses
+
+	return operation
+}
+
+func openAPIContent(body interface{}, schemas map[string]openAPISchema) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": typeSchema(reflect.TypeOf(body), schemas),
+		},
+	}
+}
+
+func constraintSchema(c *constraint) openAPISchema {
+	switch c.String() {
+	case "":
+		return openAPISchema{"type": "string"}
+	case "int":
+		return openAPISchema{"type": "integer"}
+	case "uint":
+		return openAPISchema{"type": "integer", "minimum": 0}
+	case "alpha":
+		return openAPISchema{"type": "string", "pattern": "^[a-zA-Z]+$"}
+	case "alnum":
+		return openAPISchema{"type": "string", "pattern": "^[a-zA-Z0-9]+$"}
+	case "uuid":
+		return openAPISchema{"type": "string", "format": "uuid"}
+	}
+	return openAPISchema{"type": "string", "pattern": "^(?:" + c.pattern + ")$"}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeSchema describes a Go type as a JSON schema. Named structs are added to
+// schemas and referenced, which also keeps recursive types finite.
+func typeSchema(t reflect.Type, schemas map[string]openAPISchema) openAPISchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return openAPISchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return openAPISchema{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return openAPISchema{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openAPISchema{"type": "integer", "minimum": 0}
+	case reflect.Float32:
+		return openAPISchema{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return openAPISchema{"type": "number", "format": "double"}
+	case reflect.String:
+		return openAPISchema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return openAPISchema{"type": "string", "format": "byte"}
+		}
+		return openAPISchema{"type": "array", "items": typeSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return openAPISchema{"type": "object", "additionalProperties": typeSchema(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t == timeType {
+			return openAPISchema{"type": "string", "format": "date-time"}
+		}
+
+		if len(t.Name()) < 1 {
+			return structSchema(t, schemas)
+		}
+
+		if _, exists := schemas[t.Name()]; !exists {
+			schemas[t.Name()] = openAPISchema{}
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return openAPISchema{"$ref": "#/components/schemas/" + t.Name()}
+	}
+	return openAPISchema{}
+}
+
+func structSchema(t reflect.Type, schemas map[string]openAPISchema) openAPISchema {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	addFields(t, properties, &required, schemas)
+
+	schema := openAPISchema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func addFields(t reflect.Type, properties map[string]interface{}, required *[]string, schemas map[string]openAPISchema) {
+	for pos := 0; pos < t.NumField(); pos++ {
+		field := t.Field(pos)
+		name, options := field.Name, ""
+		if tag, tagged := field.Tag.Lookup("json"); tagged {
+			if tag == "-" {
+				continue
+			}
+			if comma := strings.Index(tag, ","); comma >= 0 {
+				tag, options = tag[:comma], tag[comma:]
+			}
+			if len(tag) > 0 {
+				name = tag
+			}
+		}
+
+		embedded := field.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+
+		if field.Anonymous && name == field.Name && embedded.Kind() == reflect.Struct {
+			addFields(embedded, properties, required, schemas)
+			continue
+		}
+
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+
+		properties[name] = typeSchema(field.Type, schemas)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package router
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+type docAudit struct {
+	Created time.Time `json:"created"`
+}
+
+type docUser struct {
+	docAudit
+	ID       int64     `json:"id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email,omitempty"`
+	Friends  []docUser `json:"friends,omitempty"`
+	Password string    `json:"-"`
+	internal string
+}
+
+var _ = Describe("OpenAPI unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+		router.Get("/users/:id<int>", noopHandler, WithName("getUser"), WithDoc(RouteDoc{
+			Summary:   "Fetch a user",
+			Tags:      []string{"users"},
+			Responses: map[int]interface{}{http.StatusOK: docUser{}, http.StatusNotFound: nil},
+		}))
+		router.Post("/users", noopHandler, WithDoc(RouteDoc{
+			Request:   &docUser{},
+			Responses: map[int]interface{}{http.StatusCreated: docUser{}},
+		}))
+		router.Get("/archive/[:year<uint>]", noopHandler)
+		router.Get("/files/*path", noopHandler)
+	})
+
+	generate := func(info OpenAPIInfo) map[string]interface{} {
+		raw, err := router.OpenAPI(info)
+		Expect(err).To(BeNil())
+
+		document := make(map[string]interface{})
+		Expect(json.Unmarshal(raw, &document)).To(Succeed())
+		return document
+	}
+
+	lookup := func(value interface{}, keys ...string) interface{} {
+		for _, key := range keys {
+			Expect(value).To(HaveKey(key))
+			value = value.(map[string]interface{})[key]
+		}
+		return value
+	}
+
+	When("generating a document", func() {
+		It("should describe the API and each documented operation", func() {
+			document := generate(OpenAPIInfo{Title: "Users", Version: "1.0"})
+
+			Expect(document["openapi"]).To(Equal("3.0.3"))
+			Expect(lookup(document, "info", "title")).To(Equal("Users"))
+
+			operation := lookup(document, "paths", "/users/{id}", "get")
+			Expect(lookup(operation, "operationId")).To(Equal("getUser"))
+			Expect(lookup(operation, "summary")).To(Equal("Fetch a user"))
+			Expect(lookup(operation, "tags")).To(Equal([]interface{}{"users"}))
+			Expect(lookup(operation, "responses", "404", "description")).To(Equal("Not Found"))
+			Expect(lookup(operation, "responses", "200", "content", "application/json", "schema", "$ref")).To(Equal("#/components/schemas/docUser"))
+			Expect(lookup(document, "paths", "/users", "post", "requestBody", "content", "application/json", "schema", "$ref")).To(Equal("#/components/schemas/docUser"))
+		})
+
+		It("should derive path parameters and their schemas from the route", func() {
+			document := generate(OpenAPIInfo{})
+
+			parameters := lookup(document, "paths", "/users/{id}", "get", "parameters").([]interface{})
+			Expect(parameters).To(HaveLen(1))
+			Expect(parameters[0]).To(HaveKeyWithValue("name", "id"))
+			Expect(parameters[0]).To(HaveKeyWithValue("in", "path"))
+			Expect(lookup(parameters[0], "schema", "type")).To(Equal("integer"))
+
+			Expect(lookup(document, "paths", "/files/{path}", "get", "parameters")).To(HaveLen(1))
+		})
+
+		It("should give each optional parameter its own path", func() {
+			paths := lookup(generate(OpenAPIInfo{}), "paths")
+
+			Expect(paths).To(HaveKey("/archive"))
+			Expect(lookup(paths, "/archive", "get")).ToNot(HaveKey("parameters"))
+			Expect(lookup(paths, "/archive/{year}", "get", "parameters")).To(HaveLen(1))
+		})
+
+		It("should build struct schemas from json tags", func() {
+			schema := lookup(generate(OpenAPIInfo{}), "components", "schemas", "docUser")
+
+			properties := lookup(schema, "properties")
+			Expect(properties).To(HaveKey("created"))
+			Expect(properties).To(HaveKey("email"))
+			Expect(properties).ToNot(HaveKey("Password"))
+			Expect(properties).ToNot(HaveKey("internal"))
+			Expect(lookup(properties, "id", "format")).To(Equal("int64"))
+			Expect(lookup(properties, "created", "format")).To(Equal("date-time"))
+			Expect(lookup(properties, "friends", "items", "$ref")).To(Equal("#/components/schemas/docUser"))
+			Expect(lookup(schema, "required")).To(Equal([]interface{}{"created", "id", "name"}))
+		})
+
+		It("should prefix paths with the router root", func() {
+			router.Root("/api")
+			Expect(lookup(generate(OpenAPIInfo{}), "paths")).To(HaveKey("/api/users/{id}"))
+		})
+	})
+
+	When("serving the document", func() {
+		It("should respond with JSON and leave its own route out", func() {
+			router.ServeOpenAPI("/openapi.json", OpenAPIInfo{Title: "Users"})
+
+			r := httptest.NewRequest("GET", "/openapi.json", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Content-Type")).To(Equal("application/json"))
+
+			document := make(map[string]interface{})
+			Expect(json.Unmarshal(w.Body.Bytes(), &document)).To(Succeed())
+			Expect(lookup(document, "paths")).To(HaveKey("/users"))
+			Expect(lookup(document, "paths")).ToNot(HaveKey("/openapi.json"))
+		})
+	})
+})
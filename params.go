@@ -0,0 +1,183 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrParamMissing = errors.New("parameter is missing")
+
+// ParamError describes a query or path parameter which was missing or could
+// not be converted to the type a handler asked for.
+type ParamError struct {
+	Source string
+	Name   string
+	Value  string
+	Type   string
+	Err    error
+}
+
+func (e *ParamError) Error() string {
+	if errors.Is(e.Err, ErrParamMissing) {
+		return fmt.Sprintf("%s parameter %q is missing", e.Source, e.Name)
+	}
+	return fmt.Sprintf("%s parameter %q must be a valid %s, got %q", e.Source, e.Name, e.Type, e.Value)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+type paramErrorBody struct {
+	Error  string       `json:"error"`
+	Source string       `json:"source,omitempty"`
+	Param  string       `json:"param,omitempty"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// InvalidParam turns an error from one of the typed argument or query methods,
+// or from Bind, into a 400 response describing the problem. A body with an
+// unsupported content type is answered with a 415 instead, validation errors
+// with a 422 listing each failing field, and an ErrInvalidRule with a 500
+// after logging it.
+func (r *request) InvalidParam(err error) Response {
+	body := paramErrorBody{Error: err.Error()}
+
+	status := http.StatusBadRequest
+
+	var paramErr *ParamError
+	var bindErr *BindError
+	var validationErr ValidationErrors
+	switch {
+	case errors.As(err, &paramErr):
+		body.Source, body.Param = paramErr.Source, paramErr.Name
+	case errors.As(err, &bindErr):
+		body.Source, body.Param = bindErr.Source, bindErr.Field
+		if errors.Is(err, ErrUnsupportedMediaType) {
+			status = http.StatusUnsupportedMediaType
+		}
+	case errors.As(err, &validationErr):
+		body.Error, body.Fields = "validation failed", validationErr
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, ErrInvalidRule):
+		logError(r.log, "Router : Invalid validation rule", ":", r.input.Method, r.GetURL(), ":", err.Error())
+		body.Error = http.StatusText(http.StatusInternalServerError)
+		status = http.StatusInternalServerError
+	}
+
+	if r.problems {
+		problem := Problem{Status: status, Detail: body.Error, Extensions: make(map[string]interface{})}
+		if len(body.Source) > 0 {
+			problem.Extensions["source"] = body.Source
+		}
+		if len(body.Param) > 0 {
+			problem.Extensions["param"] = body.Param
+		}
+		if len(body.Fields) > 0 {
+			problem.Extensions["fields"] = body.Fields
+		}
+		return r.Problem(problem)
+	}
+
+	r.SetHeader("Content-Type", "application/json")
+	return r.Error(status, body)
+}
+
+func paramError(source, name, value, kind string, err error) error {
+	return &ParamError{Source: source, Name: name, Value: value, Type: kind, Err: err}
+}
+
+func parseInt(source, name, value string, exists bool) (int, error) {
+	if !exists {
+		return 0, paramError(source, name, value, "int", ErrParamMissing)
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, paramError(source, name, value, "int", err)
+	}
+	return parsed, nil
+}
+
+func parseInt64(source, name, value string, exists bool) (int64, error) {
+	if !exists {
+		return 0, paramError(source, name, value, "int64", ErrParamMissing)
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, paramError(source, name, value, "int64", err)
+	}
+	return parsed, nil
+}
+
+func parseFloat64(source, name, value string, exists bool) (float64, error) {
+	if !exists {
+		return 0, paramError(source, name, value, "float64", ErrParamMissing)
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, paramError(source, name, value, "float64", err)
+	}
+	return parsed, nil
+}
+
+func parseBool(source, name, value string, exists bool) (bool, error) {
+	if !exists {
+		return false, paramError(source, name, value, "bool", ErrParamMissing)
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, paramError(source, name, value, "bool", err)
+	}
+	return parsed, nil
+}
+
+func parseUUID(source, name, value string, exists bool) (string, error) {
+	if !exists {
+		return "", paramError(source, name, value, "uuid", ErrParamMissing)
+	}
+
+	if !isUUID(value) {
+		return "", paramError(source, name, value, "uuid", errors.New("invalid uuid"))
+	}
+	return strings.ToLower(value), nil
+}
+
+func parseTime(source, name, value string, exists bool, layout string) (time.Time, error) {
+	if !exists {
+		return time.Time{}, paramError(source, name, value, "time", ErrParamMissing)
+	}
+
+	parsed, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, paramError(source, name, value, "time", err)
+	}
+	return parsed, nil
+}
+
+func isUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+
+	for pos, char := range value {
+		switch pos {
+		case 8, 13, 18, 23:
+			if char != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", char) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+type TrailingSlash int
+
+const (
+	// TrailingSlashIgnore matches a URL whether or not it ends in a slash.
+	TrailingSlashIgnore TrailingSlash = iota
+	// TrailingSlashStrict only matches a URL ending in a slash when the route
+	// was registered with one.
+	TrailingSlashStrict
+	// TrailingSlashRedirect redirects a URL to the form the route was
+	// registered with.
+	TrailingSlashRedirect
+)
+
+// PathOptions control how request paths are treated before matching. With
+// CleanPath set, paths containing "." or ".." segments or repeated slashes are
+// redirected to their cleaned form. Redirects use 301 for GET and HEAD
+// requests and 308 for anything else, so the method and body are kept.
+type PathOptions struct {
+	TrailingSlash TrailingSlash
+	CleanPath     bool
+}
+
+func (r *Router) Paths(options PathOptions) {
+	r.paths = &options
+}
+
+func (r *Router) pathOptions() PathOptions {
+	for ; r != nil; r = r.parent {
+		if r.paths != nil {
+			return *r.paths
+		}
+	}
+	return PathOptions{}
+}
+
+// lookup matches a route against a URL beneath one of the router's roots.
+func (rt *Router) lookup(method, url string) matcher {
+	url, underRoot := rt.relativePath(url)
+	if !underRoot {
+		return matcher{}
+	}
+	return rt.match(method, url)
+}
+
+// canonicalPath returns the path a request should be redirected to, or an
+// empty string when the request path is already canonical.
+func (rt *Router) canonicalPath(r *http.Request) string {
+	requested := r.URL.Path
+	canonical := requested
+	options := rt.pathOptions()
+
+	if options.CleanPath {
+		canonical = cleanURLPath(canonical)
+	}
+
+	if options.TrailingSlash == TrailingSlashRedirect {
+		method := r.Method
+		if method == "HEAD" && rt.lookup(method, canonical).route == nil {
+			method = "GET"
+		}
+
+		if m := rt.lookup(method, canonical); m.route != nil && !trailingSlashAgrees(m.route, canonical) {
+			if hasTrailingSlash(canonical) {
+				canonical = strings.TrimRight(canonical, "/")
+			} else {
+				canonical += "/"
+			}
+		}
+	}
+
+	if canonical == requested {
+		return ""
+	}
+
+	// A leading "//" would make the redirect relative to another host
+	canonical = "/" + strings.TrimLeft(canonical, "/")
+	return (&url.URL{Path: canonical, RawQuery: r.URL.RawQuery}).String()
+}
+
+func (rt *Router) redirectPath(w http.ResponseWriter, r *http.Request, target string) {
+	status := http.StatusPermanentRedirect
+	if r.Method == "GET" || r.Method == "HEAD" {
+		status = http.StatusMovedPermanently
+	}
+	http.Redirect(w, r, target, status)
+}
+
+func cleanURLPath(requested string) string {
+	cleaned := path.Clean("/" + requested)
+	if hasTrailingSlash(requested) && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+func trailingSlashAgrees(rt *route, url string) bool {
+	if endsInWildcard(rt) {
+		return true
+	}
+	return hasTrailingSlash(rt.Path) == hasTrailingSlash(url)
+}
+
+// slashed reports whether a route was registered with a trailing slash which
+// matters when matching. Wildcard routes match either form.
+func slashed(rt *route) bool {
+	return hasTrailingSlash(rt.Path) && !endsInWildcard(rt)
+}
+
+func endsInWildcard(rt *route) bool {
+	return len(rt.tokens) > 0 && rt.tokens[len(rt.tokens)-1].kind == wildcardNode
+}
+
+func hasTrailingSlash(path string) bool {
+	return len(path) > 1 && strings.HasSuffix(path, "/")
+}
@@ -0,0 +1,164 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Path policy unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+		router.Get("/docs", func(request Request) Response {
+			return request.Success("docs")
+		})
+		router.Get("/blog/", func(request Request) Response {
+			return request.Success("blog")
+		})
+		router.Post("/docs", noopHandler)
+		router.Get("/files/*path", func(request Request) Response {
+			return request.Success(request.GetArg("path"))
+		})
+	})
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	When("trailing slashes are ignored", func() {
+		It("should match either form", func() {
+			Expect(serve("GET", "/docs/").Body.String()).To(Equal("docs"))
+			Expect(serve("GET", "/blog").Body.String()).To(Equal("blog"))
+		})
+
+		It("should report routes which only differ by a trailing slash", func() {
+			router.Get("/docs/", noopHandler)
+			Expect(router.Validate()).To(MatchError("router: duplicate route GET /docs/"))
+		})
+	})
+
+	When("trailing slashes are strict", func() {
+		BeforeEach(func() {
+			router.Paths(PathOptions{TrailingSlash: TrailingSlashStrict})
+		})
+
+		It("should only match the registered form", func() {
+			Expect(serve("GET", "/docs").Body.String()).To(Equal("docs"))
+			Expect(serve("GET", "/blog/").Body.String()).To(Equal("blog"))
+
+			Expect(serve("GET", "/docs/").Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(serve("GET", "/blog").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should not report other methods for the wrong form", func() {
+			Expect(serve("PUT", "/docs/").Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(serve("PUT", "/docs").Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should leave wildcard routes alone", func() {
+			Expect(serve("GET", "/files/a/b/").Body.String()).To(Equal("a/b"))
+		})
+
+		It("should serve both forms when both are registered", func() {
+			router.Get("/docs/", func(request Request) Response {
+				return request.Success("docs index")
+			})
+			router.Get("/blog", func(request Request) Response {
+				return request.Success("blog feed")
+			})
+
+			Expect(router.Validate()).To(BeNil())
+			Expect(serve("GET", "/docs").Body.String()).To(Equal("docs"))
+			Expect(serve("GET", "/docs/").Body.String()).To(Equal("docs index"))
+			Expect(serve("GET", "/blog").Body.String()).To(Equal("blog feed"))
+			Expect(serve("GET", "/blog/").Body.String()).To(Equal("blog"))
+		})
+
+		It("should keep looking for a route registered in the requested form", func() {
+			router.Get("/:page/", func(request Request) Response {
+				return request.Success("page " + request.GetArg("page"))
+			})
+
+			Expect(serve("GET", "/docs/").Body.String()).To(Equal("page docs"))
+		})
+	})
+
+	When("trailing slashes are redirected", func() {
+		BeforeEach(func() {
+			router.Paths(PathOptions{TrailingSlash: TrailingSlashRedirect})
+		})
+
+		It("should permanently redirect GET requests to the registered form", func() {
+			w := serve("GET", "/docs/?page=2")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMovedPermanently))
+			Expect(w.Result().Header.Get("Location")).To(Equal("/docs?page=2"))
+
+			w = serve("GET", "/blog")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMovedPermanently))
+			Expect(w.Result().Header.Get("Location")).To(Equal("/blog/"))
+		})
+
+		It("should use a 308 for other methods", func() {
+			w := serve("POST", "/docs/")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusPermanentRedirect))
+			Expect(w.Result().Header.Get("Location")).To(Equal("/docs"))
+		})
+
+		It("should serve both forms without redirecting when both are registered", func() {
+			router.Get("/docs/", func(request Request) Response {
+				return request.Success("docs index")
+			})
+
+			Expect(router.Validate()).To(BeNil())
+			Expect(serve("GET", "/docs").Body.String()).To(Equal("docs"))
+			Expect(serve("GET", "/docs/").Body.String()).To(Equal("docs index"))
+		})
+
+		It("should serve the registered form and leave unknown URLs alone", func() {
+			Expect(serve("GET", "/docs").Body.String()).To(Equal("docs"))
+			Expect(serve("GET", "/missing/").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should never redirect to another host", func() {
+			router.Get("/evil.com", noopHandler)
+			w := serve("GET", "//evil.com/")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMovedPermanently))
+			Expect(w.Result().Header.Get("Location")).To(Equal("/evil.com"))
+		})
+	})
+
+	When("paths are cleaned", func() {
+		BeforeEach(func() {
+			router.Paths(PathOptions{CleanPath: true})
+		})
+
+		It("should redirect dot segments and repeated slashes", func() {
+			w := serve("GET", "//blog/../docs/./")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMovedPermanently))
+			Expect(w.Result().Header.Get("Location")).To(Equal("/docs/"))
+
+			w = serve("DELETE", "/a//b")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusPermanentRedirect))
+			Expect(w.Result().Header.Get("Location")).To(Equal("/a/b"))
+		})
+
+		It("should serve clean paths directly", func() {
+			Expect(serve("GET", "/docs").Body.String()).To(Equal("docs"))
+		})
+
+		It("should clean and fix the trailing slash in a single redirect", func() {
+			router.Paths(PathOptions{CleanPath: true, TrailingSlash: TrailingSlashRedirect})
+
+			w := serve("GET", "/x/../docs/")
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMovedPermanently))
+			Expect(w.Result().Header.Get("Location")).To(Equal("/docs"))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is an RFC 7807 problem document. Extensions are written alongside
+// the standard members, which they cannot replace.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+func NewProblem(status int, detail string) Problem {
+	return Problem{Status: status, Detail: detail}
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	document := make(map[string]interface{}, len(p.Extensions)+5)
+	for name, value := range p.Extensions {
+		switch name {
+		case "type", "title", "status", "detail", "instance":
+			continue
+		}
+		document[name] = value
+	}
+
+	document["type"] = "about:blank"
+	if len(p.Type) > 0 {
+		document["type"] = p.Type
+	}
+
+	if len(p.Title) > 0 {
+		document["title"] = p.Title
+	}
+
+	if p.Status > 0 {
+		document["status"] = p.Status
+	}
+
+	if len(p.Detail) > 0 {
+		document["detail"] = p.Detail
+	}
+
+	if len(p.Instance) > 0 {
+		document["instance"] = p.Instance
+	}
+	return json.Marshal(document)
+}
+
+// ProblemDetails makes the router's own error responses, and those made by
+// InvalidParam, problem documents. This covers 404 and 405 responses when no
+// handler has been given for them, and panics without a panic handler. Host
+// routers made from the router use problem documents too.
+func (r *Router) ProblemDetails() {
+	r.problems = true
+}
+
+func (r *Router) problemDetails() bool {
+	for ; r != nil; r = r.parent {
+		if r.problems {
+			return true
+		}
+	}
+	return false
+}
+
+// Problem responds with a problem document. The status defaults to 500, the
+// title to the status text and the instance to the request path.
+func (r *request) Problem(problem Problem) Response {
+	if problem.Status < 1 {
+		problem.Status = http.StatusInternalServerError
+	}
+
+	if len(problem.Title) < 1 && len(problem.Type) < 1 {
+		problem.Title = http.StatusText(problem.Status)
+	}
+
+	if len(problem.Instance) < 1 {
+		problem.Instance = r.URL
+	}
+
+	document, err := json.Marshal(problem)
+	if err != nil {
+		problem.Extensions = nil
+		document, _ = json.Marshal(problem)
+	}
+
+	r.SetHeader("Content-Type", "application/problem+json")
+	r.statusCode = problem.Status
+	r.content = document
+	return r
+}
+
+// failure responds with a plain message, or with a problem document when the
+// router has been asked for them.
+func failure(in Request, status int, message string) Response {
+	if req, isRequest := in.(*request); isRequest && req.problems {
+		if message == http.StatusText(status) {
+			message = ""
+		}
+		return req.Problem(NewProblem(status, message))
+	}
+	return in.Error(status, message)
+}
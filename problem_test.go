@@ -0,0 +1,155 @@
+package router
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+type problemSignup struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"min=18"`
+}
+
+var _ = Describe("Problem details unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+		router.Logger(&recordingLog{})
+	})
+
+	serve := func(method, url, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		r := httptest.NewRequest(method, url, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		document := make(map[string]interface{})
+		if w.Result().Header.Get("Content-Type") == "application/problem+json" {
+			Expect(json.Unmarshal(w.Body.Bytes(), &document)).To(Succeed())
+		}
+		return w, document
+	}
+
+	When("a handler responds with a problem", func() {
+		It("should write a problem document with its extensions", func() {
+			router.Get("/accounts/:id", func(request Request) Response {
+				return request.Problem(Problem{
+					Type:       "https://example.org/problems/out-of-credit",
+					Title:      "You do not have enough credit",
+					Status:     http.StatusForbidden,
+					Detail:     "Your balance is 30, but that costs 50",
+					Extensions: map[string]interface{}{"balance": 30, "status": "ignored"},
+				})
+			})
+			w, document := serve("GET", "/accounts/12", "")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusForbidden))
+			Expect(w.Result().Header.Get("Content-Type")).To(Equal("application/problem+json"))
+			Expect(document).To(Equal(map[string]interface{}{
+				"type":     "https://example.org/problems/out-of-credit",
+				"title":    "You do not have enough credit",
+				"status":   float64(403),
+				"detail":   "Your balance is 30, but that costs 50",
+				"instance": "/accounts/12",
+				"balance":  float64(30),
+			}))
+		})
+
+		It("should fill in the defaults", func() {
+			router.Get("/", func(request Request) Response {
+				return request.Problem(Problem{})
+			})
+			w, document := serve("GET", "/", "")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusInternalServerError))
+			Expect(document).To(HaveKeyWithValue("type", "about:blank"))
+			Expect(document).To(HaveKeyWithValue("title", "Internal Server Error"))
+			Expect(document).ToNot(HaveKey("detail"))
+		})
+	})
+
+	When("problem details are turned on for the router", func() {
+		BeforeEach(func() {
+			router.ProblemDetails()
+			router.Get("/panic", func(request Request) Response {
+				panic("broken")
+			})
+			router.Post("/signup", func(request Request) Response {
+				var signup problemSignup
+				if err := request.BindAndValidate(&signup); err != nil {
+					return request.InvalidParam(err)
+				}
+				return request.Success("welcome")
+			})
+		})
+
+		It("should answer unknown URLs with a problem", func() {
+			w, document := serve("GET", "/missing", "")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(document).To(HaveKeyWithValue("title", "Not Found"))
+			Expect(document).To(HaveKeyWithValue("instance", "/missing"))
+		})
+
+		It("should answer the wrong method with a problem", func() {
+			w, document := serve("DELETE", "/signup", "")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(w.Result().Header.Get("Allow")).To(Equal("OPTIONS, POST"))
+			Expect(document).To(HaveKeyWithValue("status", float64(405)))
+		})
+
+		It("should answer panics with a problem", func() {
+			w, document := serve("GET", "/panic", "")
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusInternalServerError))
+			Expect(document).To(HaveKeyWithValue("title", "Internal Server Error"))
+			Expect(document).ToNot(HaveKey("detail"))
+		})
+
+		It("should answer validation failures with a problem listing the fields", func() {
+			w, document := serve("POST", "/signup", `{"name": "Ada", "email": "ada"}`)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusUnprocessableEntity))
+			Expect(document).To(HaveKeyWithValue("title", "Unprocessable Entity"))
+			Expect(document).To(HaveKey("fields"))
+			Expect(document["fields"]).To(HaveLen(2))
+		})
+
+		It("should answer binding failures with a problem naming the field", func() {
+			w, document := serve("POST", "/signup", `{"name": 12}`)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(document).To(HaveKeyWithValue("source", "body"))
+			Expect(document).To(HaveKeyWithValue("param", "name"))
+		})
+
+		It("should answer unknown URLs on host routers with a problem", func() {
+			router.Host("api.example.com").Get("/status", func(request Request) Response {
+				return request.Success("ok")
+			})
+
+			r := httptest.NewRequest("GET", "/missing", nil)
+			r.Host = "api.example.com"
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(w.Result().Header.Get("Content-Type")).To(Equal("application/problem+json"))
+		})
+
+		It("should leave custom NotFound handlers alone", func() {
+			router.NotFound(func(request Request) Response {
+				return request.Error(http.StatusNotFound, "custom")
+			})
+			w, _ := serve("GET", "/missing", "")
+
+			Expect(w.Body.String()).To(Equal("custom"))
+		})
+	})
+})
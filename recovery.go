@@ -0,0 +1,97 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// Log matches the logger used by handler-base, so the same logger can be
+// shared between a service and its router.
+type Log interface {
+	Debug(msg ...interface{})
+	Info(msg ...interface{})
+	Notice(msg ...interface{})
+	Error(msg ...interface{})
+	Alert(msg ...interface{})
+}
+
+func (r *Router) Logger(log Log) {
+	r.log = log
+}
+
+// PanicHandler replaces the default 500 response sent when a handler panics.
+// It receives the request and the value which was recovered.
+func (r *Router) PanicHandler(handler func(request Request, recovered interface{}) Response) {
+	r.panicHandler = handler
+}
+
+func (rt *Router) handle(handler Handler, req *request) (resp Response) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		if recovered == http.ErrAbortHandler {
+			panic(recovered)
+		}
+
+		rt.logError("Router : Recovered from panic", ":", req.input.Method, req.GetURL(), ":", recovered, "\n", string(debug.Stack()))
+		resp = rt.recovered(req, recovered)
+	}()
+
+	return handler(req)
+}
+
+func (r *Router) logger() Log {
+	for ; r != nil; r = r.parent {
+		if r.log != nil {
+			return r.log
+		}
+	}
+	return nil
+}
+
+func (r *Router) recoverer() func(request Request, recovered interface{}) Response {
+	for ; r != nil; r = r.parent {
+		if r.panicHandler != nil {
+			return r.panicHandler
+		}
+	}
+	return nil
+}
+
+func (rt *Router) recovered(req *request, recovered interface{}) (resp Response) {
+	req.response = response{}
+
+	handler := rt.recoverer()
+	if handler == nil {
+		return internalServerError(req)
+	}
+
+	defer func() {
+		if again := recover(); again != nil {
+			rt.logError("Router : Panic handler panicked", ":", again)
+			req.response = response{}
+			resp = internalServerError(req)
+		}
+	}()
+	return handler(req, recovered)
+}
+
+func internalServerError(request Request) Response {
+	return failure(request, http.StatusInternalServerError, "Internal Server Error")
+}
+
+func (rt *Router) logError(msg ...interface{}) {
+	logError(rt.logger(), msg...)
+}
+
+func logError(log Log, msg ...interface{}) {
+	if log != nil {
+		log.Error(msg...)
+		return
+	}
+	fmt.Println(msg...)
+}
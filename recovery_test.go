@@ -0,0 +1,110 @@
+package router
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+type recordingLog struct {
+	errors []string
+}
+
+func (l *recordingLog) Debug(msg ...interface{})  {}
+func (l *recordingLog) Info(msg ...interface{})   {}
+func (l *recordingLog) Notice(msg ...interface{}) {}
+func (l *recordingLog) Alert(msg ...interface{})  {}
+func (l *recordingLog) Error(msg ...interface{}) {
+	l.errors = append(l.errors, fmt.Sprint(msg...))
+}
+
+var _ = Describe("Panic recovery unit tests", func() {
+
+	var router Router
+	var log *recordingLog
+	BeforeEach(func() {
+		router = Router{}
+		log = &recordingLog{}
+		router.Logger(log)
+		router.Get("/panic", func(request Request) Response {
+			request.SetHeader("X-Partial", "set")
+			panic("something went wrong")
+		})
+	})
+
+	serve := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/panic", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	When("a handler panics", func() {
+		It("should respond with a 500 and discard the partial response", func() {
+			w := serve()
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusInternalServerError))
+			Expect(w.Body.String()).To(Equal("Internal Server Error"))
+			Expect(w.Result().Header.Get("X-Partial")).To(BeEmpty())
+		})
+
+		It("should report the panic and stack trace to the logger", func() {
+			serve()
+
+			Expect(log.errors).To(HaveLen(1))
+			Expect(log.errors[0]).To(ContainSubstring("something went wrong"))
+			Expect(log.errors[0]).To(ContainSubstring("recovery_test.go"))
+		})
+
+		It("should use the panic handler when one is given", func() {
+			router.PanicHandler(func(request Request, recovered interface{}) Response {
+				return request.Error(http.StatusServiceUnavailable, fmt.Sprint("recovered: ", recovered))
+			})
+			w := serve()
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusServiceUnavailable))
+			Expect(w.Body.String()).To(Equal("recovered: something went wrong"))
+		})
+
+		It("should fall back to a 500 if the panic handler panics too", func() {
+			router.PanicHandler(func(request Request, recovered interface{}) Response {
+				panic("again")
+			})
+			w := serve()
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusInternalServerError))
+			Expect(log.errors).To(HaveLen(2))
+		})
+
+		It("should recover panics raised by middleware", func() {
+			router.Use(func(handler Handler) Handler {
+				return func(request Request) Response {
+					panic("middleware failure")
+				}
+			})
+			router.Get("/", func(request Request) Response {
+				return request.Success("OK")
+			})
+
+			r := httptest.NewRequest("GET", "/", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	When("a handler aborts the request", func() {
+		It("should let http.ErrAbortHandler continue to unwind", func() {
+			router.Get("/abort", func(request Request) Response {
+				panic(http.ErrAbortHandler)
+			})
+
+			r := httptest.NewRequest("GET", "/abort", nil)
+			w := httptest.NewRecorder()
+			Expect(func() { router.ServeHTTP(w, r) }).To(PanicWith(http.ErrAbortHandler))
+		})
+	})
+})
@@ -1,6 +1,18 @@
 package router
 
+import "net/http"
+
 type route struct {
 	Method, Path string
+	Name         string
 	Handler      Handler
+	httpHandler  http.Handler
+	tokens       []token
+	cors         *CORSPolicy
+	middleware   []Middleware
+	group        *Group
+	doc          *RouteDoc
+	hidden       bool
 }
+
+type RouteOption func(*route)
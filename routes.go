@@ -0,0 +1,96 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime"
+	"text/tabwriter"
+)
+
+// RouteInfo describes a registered route. Middleware counts every middleware
+// wrapping the handler, including global and group middleware.
+type RouteInfo struct {
+	Host       string `json:"host,omitempty"`
+	Method     string `json:"method"`
+	Pattern    string `json:"pattern"`
+	Name       string `json:"name,omitempty"`
+	Middleware int    `json:"middleware"`
+	Handler    string `json:"handler"`
+}
+
+// Routes lists the registered routes in the order they were registered,
+// followed by the routes of each host router.
+func (r *Router) Routes() []RouteInfo {
+	routes := make([]RouteInfo, 0, len(r.routes))
+	for _, rt := range r.routes {
+		routes = append(routes, RouteInfo{
+			Method:     rt.Method,
+			Pattern:    rt.Path,
+			Name:       rt.Name,
+			Middleware: len(r.inheritedMiddleware()) + len(flatten(rt.group)) + len(rt.middleware),
+			Handler:    rt.handlerName(),
+		})
+	}
+
+	for _, host := range r.hosts {
+		for _, info := range host.router.Routes() {
+			if len(info.Host) < 1 {
+				info.Host = host.pattern
+			}
+			routes = append(routes, info)
+		}
+	}
+	return routes
+}
+
+// WriteRoutes prints the route table as aligned columns, for example to log it
+// at startup.
+func (r *Router) WriteRoutes(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "METHOD\tPATTERN\tNAME\tMIDDLEWARE\tHANDLER")
+
+	for _, info := range r.Routes() {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%d\t%s\n", info.Method, info.Host+info.Pattern, info.Name, info.Middleware, info.Handler)
+	}
+	return table.Flush()
+}
+
+func (r *Router) RoutesJSON() ([]byte, error) {
+	return json.Marshal(r.Routes())
+}
+
+// RouteTable is a handler which responds with the route table as JSON,
+// suitable for a debug endpoint.
+func (r *Router) RouteTable(request Request) Response {
+	routes, err := r.RoutesJSON()
+	if err != nil {
+		return request.Error(http.StatusInternalServerError, err.Error())
+	}
+
+	request.SetHeader("Content-Type", "application/json")
+	return request.Success(routes)
+}
+
+func (rt *route) handlerName() string {
+	var handler interface{} = rt.Handler
+	if rt.httpHandler != nil {
+		handler = rt.httpHandler
+	}
+
+	value := reflect.ValueOf(handler)
+	if value.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", handler)
+	}
+
+	if value.IsNil() {
+		return ""
+	}
+
+	if fn := runtime.FuncForPC(value.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
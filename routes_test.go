@@ -0,0 +1,81 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func listUsers(request Request) Response {
+	return request.Success("users")
+}
+
+var _ = Describe("Route introspection unit tests", func() {
+
+	var router Router
+	passthrough := func(handler Handler) Handler {
+		return handler
+	}
+
+	BeforeEach(func() {
+		router = Router{}
+		router.Use(passthrough)
+		router.Get("/users", listUsers, WithName("users"))
+		router.Group("/admin", func(g *Group) {
+			g.Use(passthrough)
+			g.Delete("/users/:id", noopHandler, WithMiddleware(passthrough))
+		})
+	})
+
+	When("listing the routes", func() {
+		It("should describe each route in registration order", func() {
+			routes := router.Routes()
+
+			Expect(routes).To(HaveLen(2))
+			Expect(routes[0]).To(Equal(RouteInfo{
+				Method:     "GET",
+				Pattern:    "/users",
+				Name:       "users",
+				Middleware: 1,
+				Handler:    "github.com/driscollcode/router.listUsers",
+			}))
+			Expect(routes[1].Method).To(Equal("DELETE"))
+			Expect(routes[1].Pattern).To(Equal("/admin/users/:id"))
+			Expect(routes[1].Middleware).To(Equal(3))
+		})
+	})
+
+	When("printing the route table", func() {
+		It("should write a header and one aligned row per route", func() {
+			var out bytes.Buffer
+			Expect(router.WriteRoutes(&out)).To(Succeed())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(HavePrefix("METHOD  PATTERN"))
+			Expect(lines[1]).To(ContainSubstring("/users"))
+			Expect(lines[1]).To(HaveSuffix("router.listUsers"))
+			Expect(strings.Index(lines[1], "/users")).To(Equal(strings.Index(lines[2], "/admin")))
+		})
+
+		It("should serve the route table as JSON", func() {
+			router.Get("/debug/routes", router.RouteTable)
+
+			r := httptest.NewRequest("GET", "/debug/routes", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(w.Result().Header.Get("Content-Type")).To(Equal("application/json"))
+
+			var routes []RouteInfo
+			Expect(json.Unmarshal(w.Body.Bytes(), &routes)).To(Succeed())
+			Expect(routes).To(HaveLen(3))
+			Expect(routes[0].Name).To(Equal("users"))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package router
+
+import (
+	stdLog "log"
+	"net/http"
+	"time"
+)
+
+// ServerOptions configure the http.Server behind every Serve method. Zero
+// values fall back to DefaultServerOptions and negative durations disable the
+// corresponding timeout.
+type ServerOptions struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ErrorLog          *stdLog.Logger
+}
+
+func DefaultServerOptions() ServerOptions {
+	return ServerOptions{
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    http.DefaultMaxHeaderBytes,
+	}
+}
+
+// Server builds the http.Server the Serve methods use, for callers who want
+// to run it themselves.
+func (rt *Router) Server(addr string, opts ...ServerOptions) *http.Server {
+	return rt.server(addr, nil, opts)
+}
+
+func (rt *Router) server(addr string, errorLog *stdLog.Logger, opts []ServerOptions) *http.Server {
+	options := DefaultServerOptions()
+	options.ErrorLog = errorLog
+	for _, opt := range opts {
+		options = options.merge(opt)
+	}
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           rt,
+		ReadHeaderTimeout: timeout(options.ReadHeaderTimeout),
+		ReadTimeout:       timeout(options.ReadTimeout),
+		WriteTimeout:      timeout(options.WriteTimeout),
+		IdleTimeout:       timeout(options.IdleTimeout),
+		MaxHeaderBytes:    options.MaxHeaderBytes,
+		ErrorLog:          options.ErrorLog,
+	}
+}
+
+func (o ServerOptions) merge(other ServerOptions) ServerOptions {
+	if other.ReadHeaderTimeout != 0 {
+		o.ReadHeaderTimeout = other.ReadHeaderTimeout
+	}
+
+	if other.ReadTimeout != 0 {
+		o.ReadTimeout = other.ReadTimeout
+	}
+
+	if other.WriteTimeout != 0 {
+		o.WriteTimeout = other.WriteTimeout
+	}
+
+	if other.IdleTimeout != 0 {
+		o.IdleTimeout = other.IdleTimeout
+	}
+
+	if other.MaxHeaderBytes > 0 {
+		o.MaxHeaderBytes = other.MaxHeaderBytes
+	}
+
+	if other.ErrorLog != nil {
+		o.ErrorLog = other.ErrorLog
+	}
+	return o
+}
+
+func timeout(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return 0
+	}
+	return duration
+}
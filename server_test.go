@@ -0,0 +1,60 @@
+package router
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	stdLog "log"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Server options unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	When("no options are given", func() {
+		It("should build a server with the default timeouts and limits", func() {
+			s := router.Server(":8080")
+
+			Expect(s.Addr).To(Equal(":8080"))
+			Expect(s.Handler).To(Equal(&router))
+			Expect(s.ReadHeaderTimeout).To(Equal(10 * time.Second))
+			Expect(s.ReadTimeout).To(Equal(30 * time.Second))
+			Expect(s.WriteTimeout).To(Equal(60 * time.Second))
+			Expect(s.IdleTimeout).To(Equal(120 * time.Second))
+			Expect(s.MaxHeaderBytes).To(Equal(http.DefaultMaxHeaderBytes))
+			Expect(s.ErrorLog).To(BeNil())
+		})
+	})
+
+	When("options are given", func() {
+		It("should override only the options which are set", func() {
+			errorLog := stdLog.New(&bytes.Buffer{}, "", 0)
+			s := router.Server(":8080", ServerOptions{WriteTimeout: 5 * time.Second, MaxHeaderBytes: 4096, ErrorLog: errorLog})
+
+			Expect(s.ReadHeaderTimeout).To(Equal(10 * time.Second))
+			Expect(s.WriteTimeout).To(Equal(5 * time.Second))
+			Expect(s.MaxHeaderBytes).To(Equal(4096))
+			Expect(s.ErrorLog).To(Equal(errorLog))
+		})
+
+		It("should disable a timeout which is negative", func() {
+			s := router.Server(":8080", ServerOptions{WriteTimeout: -1})
+			Expect(s.WriteTimeout).To(BeZero())
+		})
+	})
+
+	When("a TLS server is built", func() {
+		It("should let a supplied error log replace the discarding logger", func() {
+			discard := stdLog.New(&bytes.Buffer{}, "/", 0)
+			errorLog := stdLog.New(&bytes.Buffer{}, "", 0)
+
+			Expect(router.server(":443", discard, nil).ErrorLog).To(Equal(discard))
+			Expect(router.server(":443", discard, []ServerOptions{{ErrorLog: errorLog}}).ErrorLog).To(Equal(errorLog))
+		})
+	})
+})
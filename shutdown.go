@@ -0,0 +1,114 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	stdLog "log"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+// ShutdownOptions control how the context aware Serve methods stop. Once the
+// context is cancelled the router reports itself as not ready, waits for
+// ReadinessDelay so load balancers can stop sending traffic, then drains
+// in-flight requests for up to DrainTimeout (30 seconds by default).
+type ShutdownOptions struct {
+	DrainTimeout   time.Duration
+	ReadinessDelay time.Duration
+}
+
+// OnShutdown registers a function to run once a context aware Serve method
+// has finished draining requests.
+func (r *Router) OnShutdown(hook func()) {
+	r.onShutdown = append(r.onShutdown, hook)
+}
+
+func (r *Router) Ready() bool {
+	return atomic.LoadInt32(&r.draining) == 0
+}
+
+// Readiness is a handler suitable for a readiness probe. It responds 200 until
+// the router begins shutting down and 503 afterwards.
+func (r *Router) Readiness(request Request) Response {
+	if !r.Ready() {
+		return request.Error(http.StatusServiceUnavailable, "shutting down")
+	}
+	return request.Success("ready")
+}
+
+func (rt *Router) ServeContext(ctx context.Context, port int, opts ShutdownOptions, server ...ServerOptions) error {
+	if err := rt.Validate(); err != nil {
+		return err
+	}
+
+	s := rt.server(fmt.Sprintf(":%d", port), nil, server)
+	return rt.serveContext(ctx, s, opts, s.ListenAndServe)
+}
+
+func (rt *Router) ServeIPContext(ctx context.Context, ip string, port int, opts ShutdownOptions, server ...ServerOptions) error {
+	if err := rt.Validate(); err != nil {
+		return err
+	}
+
+	s := rt.server(fmt.Sprintf("%s:%d", ip, port), nil, server)
+	return rt.serveContext(ctx, s, opts, s.ListenAndServe)
+}
+
+func (rt *Router) ServeWithTLSContext(ctx context.Context, port int, key, cert string, opts ShutdownOptions, server ...ServerOptions) error {
+	err := rt.Validate()
+	if err != nil {
+		return err
+	}
+
+	if key, cert, err = rt.tlsFiles(key, cert); err != nil {
+		return err
+	}
+
+	s := rt.server(fmt.Sprintf(":%d", port), stdLog.New(ioutil.Discard, "/", 0), server)
+	return rt.serveContext(ctx, s, opts, func() error {
+		return s.ListenAndServeTLS(cert, key)
+	})
+}
+
+func (rt *Router) serveContext(ctx context.Context, s *http.Server, opts ShutdownOptions, listen func() error) error {
+	atomic.StoreInt32(&rt.draining, 0)
+
+	served := make(chan error, 1)
+	go func() {
+		served <- listen()
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	atomic.StoreInt32(&rt.draining, 1)
+	if opts.ReadinessDelay > 0 {
+		time.Sleep(opts.ReadinessDelay)
+	}
+
+	drainTimeout := opts.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	err := s.Shutdown(drainCtx)
+	if served := <-served; served != nil && !errors.Is(served, http.ErrServerClosed) && err == nil {
+		err = served
+	}
+
+	for _, hook := range rt.onShutdown {
+		hook()
+	}
+	return err
+}
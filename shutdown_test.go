@@ -0,0 +1,112 @@
+package router
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+var _ = Describe("Graceful shutdown unit tests", func() {
+
+	var (
+		router   *Router
+		listener net.Listener
+		server   *http.Server
+		release  chan struct{}
+	)
+
+	BeforeEach(func() {
+		var err error
+		router = &Router{}
+		release = make(chan struct{})
+		router.Get("/slow", func(request Request) Response {
+			<-release
+			return request.Success("finished")
+		})
+		router.Get("/ready", router.Readiness)
+
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		server = &http.Server{Handler: router}
+	})
+
+	get := func(path string) (int, string, error) {
+		resp, err := http.Get("http://" + listener.Addr().String() + path)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(body), nil
+	}
+
+	When("the context is cancelled", func() {
+		It("should drain in-flight requests before returning and then run the shutdown hooks", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			hookRan := false
+			router.OnShutdown(func() { hookRan = true })
+
+			served := make(chan error, 1)
+			go func() {
+				served <- router.serveContext(ctx, server, ShutdownOptions{DrainTimeout: 5 * time.Second}, func() error {
+					return server.Serve(listener)
+				})
+			}()
+
+			Eventually(func() int { status, _, _ := get("/ready"); return status }).Should(Equal(http.StatusOK))
+
+			response := make(chan string, 1)
+			go func() {
+				_, body, _ := get("/slow")
+				response <- body
+			}()
+			time.Sleep(50 * time.Millisecond)
+
+			cancel()
+			Eventually(router.Ready).Should(BeFalse())
+			Consistently(served, "100ms").ShouldNot(Receive())
+
+			close(release)
+			Eventually(response).Should(Receive(Equal("finished")))
+			Eventually(served).Should(Receive(BeNil()))
+			Expect(hookRan).To(BeTrue())
+		})
+
+		It("should report not ready while waiting for the readiness delay", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			close(release)
+
+			served := make(chan error, 1)
+			go func() {
+				served <- router.serveContext(ctx, server, ShutdownOptions{ReadinessDelay: 300 * time.Millisecond}, func() error {
+					return server.Serve(listener)
+				})
+			}()
+
+			Eventually(func() int { status, _, _ := get("/ready"); return status }).Should(Equal(http.StatusOK))
+			cancel()
+
+			Eventually(func() int { status, _, _ := get("/ready"); return status }).Should(Equal(http.StatusServiceUnavailable))
+			status, body, err := get("/slow")
+			Expect(err).To(BeNil())
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal("finished"))
+
+			Eventually(served).Should(Receive(BeNil()))
+		})
+	})
+
+	When("the server cannot start", func() {
+		It("should return the error straight away", func() {
+			listener.Close()
+			err := router.serveContext(context.Background(), server, ShutdownOptions{}, func() error {
+				return server.Serve(listener)
+			})
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
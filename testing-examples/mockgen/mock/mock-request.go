@@ -5,7 +5,9 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	router "github.com/driscollcode/router"
 	gomock "github.com/golang/mock/gomock"
@@ -48,6 +50,34 @@ func (mr *MockRequestMockRecorder) ArgExists(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArgExists", reflect.TypeOf((*MockRequest)(nil).ArgExists), arg0)
 }
 
+// Bind mocks base method.
+func (m *MockRequest) Bind(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bind", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Bind indicates an expected call of Bind.
+func (mr *MockRequestMockRecorder) Bind(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bind", reflect.TypeOf((*MockRequest)(nil).Bind), arg0)
+}
+
+// BindAndValidate mocks base method.
+func (m *MockRequest) BindAndValidate(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindAndValidate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BindAndValidate indicates an expected call of BindAndValidate.
+func (mr *MockRequestMockRecorder) BindAndValidate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindAndValidate", reflect.TypeOf((*MockRequest)(nil).BindAndValidate), arg0)
+}
+
 // Body mocks base method.
 func (m *MockRequest) Body() []byte {
 	m.ctrl.T.Helper()
@@ -76,6 +106,20 @@ func (mr *MockRequestMockRecorder) BodyError() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BodyError", reflect.TypeOf((*MockRequest)(nil).BodyError))
 }
 
+// Context mocks base method.
+func (m *MockRequest) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockRequestMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockRequest)(nil).Context))
+}
+
 // Error mocks base method.
 func (m *MockRequest) Error(arg0 ...interface{}) router.Response {
 	m.ctrl.T.Helper()
@@ -94,6 +138,20 @@ func (mr *MockRequestMockRecorder) Error(arg0 ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockRequest)(nil).Error), arg0...)
 }
 
+// Get mocks base method.
+func (m *MockRequest) Get(arg0 string) interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRequestMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRequest)(nil).Get), arg0)
+}
+
 // GetArg mocks base method.
 func (m *MockRequest) GetArg(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -108,6 +166,96 @@ func (mr *MockRequestMockRecorder) GetArg(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArg", reflect.TypeOf((*MockRequest)(nil).GetArg), arg0)
 }
 
+// GetArgBool mocks base method.
+func (m *MockRequest) GetArgBool(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArgBool", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArgBool indicates an expected call of GetArgBool.
+func (mr *MockRequestMockRecorder) GetArgBool(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgBool", reflect.TypeOf((*MockRequest)(nil).GetArgBool), arg0)
+}
+
+// GetArgFloat64 mocks base method.
+func (m *MockRequest) GetArgFloat64(arg0 string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArgFloat64", arg0)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArgFloat64 indicates an expected call of GetArgFloat64.
+func (mr *MockRequestMockRecorder) GetArgFloat64(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgFloat64", reflect.TypeOf((*MockRequest)(nil).GetArgFloat64), arg0)
+}
+
+// GetArgInt mocks base method.
+func (m *MockRequest) GetArgInt(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArgInt", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArgInt indicates an expected call of GetArgInt.
+func (mr *MockRequestMockRecorder) GetArgInt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgInt", reflect.TypeOf((*MockRequest)(nil).GetArgInt), arg0)
+}
+
+// GetArgInt64 mocks base method.
+func (m *MockRequest) GetArgInt64(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArgInt64", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArgInt64 indicates an expected call of GetArgInt64.
+func (mr *MockRequestMockRecorder) GetArgInt64(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgInt64", reflect.TypeOf((*MockRequest)(nil).GetArgInt64), arg0)
+}
+
+// GetArgTime mocks base method.
+func (m *MockRequest) GetArgTime(arg0, arg1 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArgTime", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArgTime indicates an expected call of GetArgTime.
+func (mr *MockRequestMockRecorder) GetArgTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgTime", reflect.TypeOf((*MockRequest)(nil).GetArgTime), arg0, arg1)
+}
+
+// GetArgUUID mocks base method.
+func (m *MockRequest) GetArgUUID(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArgUUID", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArgUUID indicates an expected call of GetArgUUID.
+func (mr *MockRequestMockRecorder) GetArgUUID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgUUID", reflect.TypeOf((*MockRequest)(nil).GetArgUUID), arg0)
+}
+
 // GetHeader mocks base method.
 func (m *MockRequest) GetHeader(arg0 string) string {
 	m.ctrl.T.Helper()
@@ -178,6 +326,94 @@ func (mr *MockRequestMockRecorder) GetPostVariable(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostVariable", reflect.TypeOf((*MockRequest)(nil).GetPostVariable), arg0)
 }
 
+// GetQuery mocks base method.
+func (m *MockRequest) GetQuery(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuery", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetQuery indicates an expected call of GetQuery.
+func (mr *MockRequestMockRecorder) GetQuery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuery", reflect.TypeOf((*MockRequest)(nil).GetQuery), arg0)
+}
+
+// GetQueryAll mocks base method.
+func (m *MockRequest) GetQueryAll(arg0 string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryAll", arg0)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetQueryAll indicates an expected call of GetQueryAll.
+func (mr *MockRequestMockRecorder) GetQueryAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryAll", reflect.TypeOf((*MockRequest)(nil).GetQueryAll), arg0)
+}
+
+// GetQueryBool mocks base method.
+func (m *MockRequest) GetQueryBool(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryBool", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryBool indicates an expected call of GetQueryBool.
+func (mr *MockRequestMockRecorder) GetQueryBool(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryBool", reflect.TypeOf((*MockRequest)(nil).GetQueryBool), arg0)
+}
+
+// GetQueryFloat64 mocks base method.
+func (m *MockRequest) GetQueryFloat64(arg0 string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryFloat64", arg0)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryFloat64 indicates an expected call of GetQueryFloat64.
+func (mr *MockRequestMockRecorder) GetQueryFloat64(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryFloat64", reflect.TypeOf((*MockRequest)(nil).GetQueryFloat64), arg0)
+}
+
+// GetQueryInt mocks base method.
+func (m *MockRequest) GetQueryInt(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryInt", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryInt indicates an expected call of GetQueryInt.
+func (mr *MockRequestMockRecorder) GetQueryInt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryInt", reflect.TypeOf((*MockRequest)(nil).GetQueryInt), arg0)
+}
+
+// GetQueryInt64 mocks base method.
+func (m *MockRequest) GetQueryInt64(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryInt64", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueryInt64 indicates an expected call of GetQueryInt64.
+func (mr *MockRequestMockRecorder) GetQueryInt64(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryInt64", reflect.TypeOf((*MockRequest)(nil).GetQueryInt64), arg0)
+}
+
 // GetReferer mocks base method.
 func (m *MockRequest) GetReferer() string {
 	m.ctrl.T.Helper()
@@ -192,6 +428,20 @@ func (mr *MockRequestMockRecorder) GetReferer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferer", reflect.TypeOf((*MockRequest)(nil).GetReferer))
 }
 
+// GetRelativeURL mocks base method.
+func (m *MockRequest) GetRelativeURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelativeURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetRelativeURL indicates an expected call of GetRelativeURL.
+func (mr *MockRequestMockRecorder) GetRelativeURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelativeURL", reflect.TypeOf((*MockRequest)(nil).GetRelativeURL))
+}
+
 // GetResponseContent mocks base method.
 func (m *MockRequest) GetResponseContent() []byte {
 	m.ctrl.T.Helper()
@@ -304,6 +554,20 @@ func (mr *MockRequestMockRecorder) HeaderExists(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeaderExists", reflect.TypeOf((*MockRequest)(nil).HeaderExists), arg0)
 }
 
+// InvalidParam mocks base method.
+func (m *MockRequest) InvalidParam(arg0 error) router.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidParam", arg0)
+	ret0, _ := ret[0].(router.Response)
+	return ret0
+}
+
+// InvalidParam indicates an expected call of InvalidParam.
+func (mr *MockRequestMockRecorder) InvalidParam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidParam", reflect.TypeOf((*MockRequest)(nil).InvalidParam), arg0)
+}
+
 // PermanentRedirect mocks base method.
 func (m *MockRequest) PermanentRedirect(arg0 string) router.Response {
 	m.ctrl.T.Helper()
@@ -332,6 +596,34 @@ func (mr *MockRequestMockRecorder) PostVariableExists(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostVariableExists", reflect.TypeOf((*MockRequest)(nil).PostVariableExists), arg0)
 }
 
+// Problem mocks base method.
+func (m *MockRequest) Problem(arg0 router.Problem) router.Response {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Problem", arg0)
+	ret0, _ := ret[0].(router.Response)
+	return ret0
+}
+
+// Problem indicates an expected call of Problem.
+func (mr *MockRequestMockRecorder) Problem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Problem", reflect.TypeOf((*MockRequest)(nil).Problem), arg0)
+}
+
+// QueryExists mocks base method.
+func (m *MockRequest) QueryExists(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryExists", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// QueryExists indicates an expected call of QueryExists.
+func (mr *MockRequestMockRecorder) QueryExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryExists", reflect.TypeOf((*MockRequest)(nil).QueryExists), arg0)
+}
+
 // Redirect mocks base method.
 func (m *MockRequest) Redirect(arg0 string) router.Response {
 	m.ctrl.T.Helper()
@@ -364,6 +656,30 @@ func (mr *MockRequestMockRecorder) Response(arg0 ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Response", reflect.TypeOf((*MockRequest)(nil).Response), arg0...)
 }
 
+// Set mocks base method.
+func (m *MockRequest) Set(arg0 string, arg1 interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", arg0, arg1)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRequestMockRecorder) Set(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRequest)(nil).Set), arg0, arg1)
+}
+
+// SetContext mocks base method.
+func (m *MockRequest) SetContext(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetContext", arg0)
+}
+
+// SetContext indicates an expected call of SetContext.
+func (mr *MockRequestMockRecorder) SetContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContext", reflect.TypeOf((*MockRequest)(nil).SetContext), arg0)
+}
+
 // SetHeader mocks base method.
 func (m *MockRequest) SetHeader(arg0, arg1 string) {
 	m.ctrl.T.Helper()
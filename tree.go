@@ -0,0 +1,369 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type nodeKind uint8
+
+const (
+	staticNode nodeKind = iota
+	paramNode
+	optionalNode
+	wildcardNode
+)
+
+// node is one edge of a compressed radix tree. Static nodes hold a run of
+// literal path text, parameter nodes consume exactly one "/segment" and
+// wildcard nodes consume whatever remains of the path. A route registered with
+// a trailing slash is kept in slashRoute, so that "/docs" and "/docs/" can be
+// told apart.
+type node struct {
+	kind       nodeKind
+	path       string
+	constraint *constraint
+	indices    string
+	children   []*node
+	params     []*node
+	route      *route
+	slashRoute *route
+}
+
+type token struct {
+	kind       nodeKind
+	text       string
+	constraint *constraint
+}
+
+type capture struct {
+	name, value string
+}
+
+// matcher walks the tree for a single request. Captures are only allocated
+// once a parameter is consumed, so static routes match without allocating.
+// slash records whether the request path ended in a slash, and strict whether
+// only a route registered the same way may match it.
+type matcher struct {
+	captures []capture
+	best     []capture
+	route    *route
+	slash    bool
+	strict   bool
+}
+
+func (n *node) insert(tokens []token, rt *route) {
+	if len(tokens) < 1 {
+		slot := &n.route
+		if slashed(rt) {
+			slot = &n.slashRoute
+		}
+
+		if *slot == nil {
+			*slot = rt
+		}
+		return
+	}
+
+	if tokens[0].kind == staticNode {
+		n.insertStatic(tokens[0].text, tokens[1:], rt)
+		return
+	}
+
+	for _, child := range n.params {
+		if child.kind == tokens[0].kind && child.path == tokens[0].text && child.constraint.String() == tokens[0].constraint.String() {
+			child.insert(tokens[1:], rt)
+			return
+		}
+	}
+
+	child := &node{kind: tokens[0].kind, path: tokens[0].text, constraint: tokens[0].constraint}
+	n.params = append(n.params, child)
+	sort.SliceStable(n.params, func(i, j int) bool {
+		return n.params[i].before(n.params[j])
+	})
+	child.insert(tokens[1:], rt)
+}
+
+// before orders the parameter children of a node so that more specific
+// parameters are tried first: constrained before plain, then optional
+// parameters and finally wildcards. Constrained parameters of the same kind
+// try built in constraints before regular expressions, then go by pattern, so
+// registration order never decides between them.
+func (n *node) before(other *node) bool {
+	if n.priority() != other.priority() {
+		return n.priority() < other.priority()
+	}
+
+	if n.constraint == nil || other.constraint == nil {
+		return false
+	}
+
+	if n.constraint.builtin() != other.constraint.builtin() {
+		return n.constraint.builtin()
+	}
+	return n.constraint.pattern < other.constraint.pattern
+}
+
+func (n *node) priority() int {
+	priority := int(n.kind-paramNode) * 2
+	if n.constraint == nil {
+		priority++
+	}
+	return priority
+}
+
+func (n *node) insertStatic(text string, tokens []token, rt *route) {
+	pos := strings.IndexByte(n.indices, text[0])
+	if pos < 0 {
+		child := &node{kind: staticNode, path: text}
+		n.indices += text[:1]
+		n.children = append(n.children, child)
+		child.insert(tokens, rt)
+		return
+	}
+
+	child := n.children[pos]
+	common := commonPrefix(child.path, text)
+
+	if common < len(child.path) {
+		rest := &node{
+			kind:       staticNode,
+			path:       child.path[common:],
+			indices:    child.indices,
+			children:   child.children,
+			params:     child.params,
+			route:      child.route,
+			slashRoute: child.slashRoute,
+		}
+		child.path = child.path[:common]
+		child.indices = rest.path[:1]
+		child.children = []*node{rest}
+		child.params = nil
+		child.route = nil
+		child.slashRoute = nil
+	}
+
+	if common == len(text) {
+		child.insert(tokens, rt)
+		return
+	}
+	child.insertStatic(text[common:], tokens, rt)
+}
+
+func (m *matcher) search(n *node, path string) {
+	switch n.kind {
+	case staticNode:
+		if !strings.HasPrefix(path, n.path) {
+			return
+		}
+		path = path[len(n.path):]
+
+	case wildcardNode:
+		if n.route == nil || (len(path) > 0 && path[0] != '/') {
+			return
+		}
+
+		value := ""
+		if len(path) > 0 {
+			value = path[1:]
+		}
+
+		if n.constraint != nil && !n.constraint.check(value) {
+			return
+		}
+
+		m.captures = append(m.captures, capture{name: n.path, value: value})
+		m.found(n.route)
+		return
+
+	case optionalNode:
+		if len(path) < 1 {
+			m.descend(n, path)
+			return
+		}
+		fallthrough
+
+	case paramNode:
+		if len(path) < 1 || path[0] != '/' {
+			return
+		}
+
+		end := strings.IndexByte(path[1:], '/') + 1
+		if end < 1 {
+			end = len(path)
+		}
+
+		if n.constraint != nil && !n.constraint.check(path[1:end]) {
+			return
+		}
+		m.captures = append(m.captures, capture{name: n.path, value: path[1:end]})
+		path = path[end:]
+	}
+
+	m.descend(n, path)
+}
+
+// descend tries the children of a node from most to least specific, stopping
+// at the first route which matches the whole path.
+func (m *matcher) descend(n *node, path string) {
+	if len(path) < 1 {
+		if rt := n.leaf(m.slash, m.strict); rt != nil {
+			m.found(rt)
+			return
+		}
+	}
+
+	if len(path) > 0 {
+		if pos := strings.IndexByte(n.indices, path[0]); pos >= 0 {
+			m.search(n.children[pos], path)
+			if m.route != nil {
+				return
+			}
+		}
+	}
+
+	for _, child := range n.params {
+		depth := len(m.captures)
+		m.search(child, path)
+		m.captures = m.captures[:depth]
+
+		if m.route != nil {
+			return
+		}
+	}
+}
+
+// leaf picks the route registered in the same form as the request, falling
+// back to the other form unless trailing slashes are strict.
+func (n *node) leaf(slash, strict bool) *route {
+	preferred, other := n.route, n.slashRoute
+	if slash {
+		preferred, other = other, preferred
+	}
+
+	if preferred != nil || strict {
+		return preferred
+	}
+	return other
+}
+
+func (m *matcher) found(rt *route) {
+	m.route = rt
+	m.best = append(m.best[:0], m.captures...)
+}
+
+func (m *matcher) args() map[string]string {
+	if len(m.best) < 1 {
+		return nil
+	}
+
+	args := make(map[string]string, len(m.best))
+	for _, c := range m.best {
+		args[c.name] = c.value
+	}
+	return args
+}
+
+func tokenise(path string) ([]token, error) {
+	tokens := make([]token, 0)
+	static := ""
+
+	bits := strings.Split(strings.Trim(path, "/"), "/")
+	for pos, bit := range bits {
+		kind, name := staticNode, bit
+		switch {
+		case len(bit) > 1 && bit[0:1] == "*":
+			if pos < len(bits)-1 {
+				return nil, fmt.Errorf("route %q, wildcard %q must be the last segment", path, bit)
+			}
+			kind, name = wildcardNode, bit[1:]
+		case len(bit) > 1 && bit[0:2] == "[:":
+			if bit[len(bit)-1:] != "]" {
+				return nil, fmt.Errorf("route %q, optional parameter %q is missing its closing ]", path, bit)
+			}
+			kind, name = optionalNode, bit[2:len(bit)-1]
+		case len(bit) > 1 && bit[0:1] == ":":
+			kind, name = paramNode, bit[1:]
+		}
+
+		if kind == staticNode {
+			static += "/" + bit
+			continue
+		}
+
+		param := token{kind: kind, text: name}
+		if name, pattern, constrained := splitConstraint(name); constrained {
+			var err error
+			if param.constraint, err = newConstraint(pattern); err != nil {
+				return nil, fmt.Errorf("route %q, parameter %q: %v", path, name, err)
+			}
+			param.text = name
+		}
+
+		if err := checkParamName(param.text); err != nil {
+			return nil, fmt.Errorf("route %q, parameter %q: %v", path, bit, err)
+		}
+
+		tokens = appendStatic(tokens, static)
+		tokens = append(tokens, param)
+		static = ""
+	}
+
+	return appendStatic(tokens, static), nil
+}
+
+// shape describes the URLs a route can match, ignoring parameter names, so that
+// two routes with the same shape are ambiguous.
+func shape(tokens []token) string {
+	parts := make([]string, 0)
+	for _, t := range tokens {
+		switch t.kind {
+		case staticNode:
+			parts = append(parts, t.text)
+		case paramNode:
+			parts = append(parts, "/:<"+t.constraint.String()+">")
+		case optionalNode:
+			parts = append(parts, "/[:<"+t.constraint.String()+">]")
+		case wildcardNode:
+			parts = append(parts, "/*<"+t.constraint.String()+">")
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func appendStatic(tokens []token, static string) []token {
+	if len(static) < 1 {
+		return tokens
+	}
+	return append(tokens, token{kind: staticNode, text: static})
+}
+
+// cleanPath trims surrounding slashes and leaves a single leading one, reusing
+// the original string where possible.
+func cleanPath(path string) string {
+	trimmed := strings.Trim(path, "/")
+	if len(trimmed) < 1 {
+		return "/"
+	}
+
+	start := len(path) - len(strings.TrimLeft(path, "/"))
+	if start < 1 {
+		return "/" + trimmed
+	}
+	return path[start-1 : start+len(trimmed)]
+}
+
+func commonPrefix(a, b string) int {
+	max := len(a)
+	if len(b) < max {
+		max = len(b)
+	}
+
+	pos := 0
+	for pos < max && a[pos] == b[pos] {
+		pos++
+	}
+	return pos
+}
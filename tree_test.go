@@ -0,0 +1,254 @@
+package router
+
+import (
+	"fmt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// routeTable mirrors the routes exercised in Router_test.go.
+var routeTable = []struct{ method, path, url string }{
+	{"GET", "/", "/"},
+	{"POST", "/", "/"},
+	{"PUT", "/", "/"},
+	{"PATCH", "/", "/"},
+	{"DELETE", "/", "/"},
+	{"CUSTOM", "/", "/"},
+	{"GET", "/defined/route", "/defined/route"},
+	{"GET", "/url/param/:one", "/url/param/working"},
+	{"GET", "/here", "/here"},
+}
+
+func noopHandler(request Request) Response {
+	return request.Success()
+}
+
+var _ = Describe("Radix tree matching", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	find := func(method, url string) (*route, map[string]string) {
+		m := router.match(method, url)
+		return m.route, m.args()
+	}
+
+	When("routes share a static prefix", func() {
+		It("should split the shared prefix and still find each route", func() {
+			router.Get("/user", noopHandler)
+			router.Get("/users", noopHandler)
+			router.Get("/user/profile", noopHandler)
+
+			for _, url := range []string{"/user", "/users", "/user/profile", "/user/"} {
+				rt, _ := find("GET", url)
+				Expect(rt).NotTo(BeNil(), url)
+				Expect(cleanPath(rt.Path)).To(Equal(cleanPath(url)))
+			}
+
+			rt, _ := find("GET", "/use")
+			Expect(rt).To(BeNil())
+		})
+	})
+
+	When("a route has parameters", func() {
+		It("should capture mandatory and optional parameters", func() {
+			router.Get("/user/:id/[:tab]", noopHandler)
+
+			rt, args := find("GET", "/user/12/posts")
+			Expect(rt).NotTo(BeNil())
+			Expect(args).To(Equal(map[string]string{"id": "12", "tab": "posts"}))
+
+			rt, args = find("GET", "/user/12")
+			Expect(rt).NotTo(BeNil())
+			Expect(args).To(Equal(map[string]string{"id": "12"}))
+
+			rt, _ = find("GET", "/user")
+			Expect(rt).To(BeNil())
+
+			rt, _ = find("GET", "/user/12/posts/extra")
+			Expect(rt).To(BeNil())
+		})
+	})
+
+	When("several routes match the same URL", func() {
+		It("should prefer the most specific route regardless of registration order", func() {
+			router.Get("/user/*rest", noopHandler)
+			router.Get("/user/[:tab]", noopHandler)
+			router.Get("/user/:id", noopHandler)
+			router.Get("/user/:id<int>", noopHandler)
+			router.Get("/user/me", noopHandler)
+
+			rt, _ := find("GET", "/user/me")
+			Expect(rt.Path).To(Equal("/user/me"))
+
+			rt, args := find("GET", "/user/12")
+			Expect(rt.Path).To(Equal("/user/:id<int>"))
+			Expect(args).To(Equal(map[string]string{"id": "12"}))
+
+			rt, _ = find("GET", "/user/bob")
+			Expect(rt.Path).To(Equal("/user/:id"))
+
+			rt, args = find("GET", "/user")
+			Expect(rt.Path).To(Equal("/user/[:tab]"))
+			Expect(args).To(BeNil())
+
+			rt, _ = find("GET", "/user/bob/posts")
+			Expect(rt.Path).To(Equal("/user/*rest"))
+		})
+
+		It("should backtrack when a more specific branch fails further along", func() {
+			router.Get("/a/b/d", noopHandler)
+			router.Get("/a/:x/c", noopHandler)
+
+			rt, args := find("GET", "/a/b/c")
+			Expect(rt.Path).To(Equal("/a/:x/c"))
+			Expect(args).To(Equal(map[string]string{"x": "b"}))
+		})
+	})
+
+	When("the route table from the router tests is loaded", func() {
+		It("should agree with the previous linear matcher", func() {
+			for _, entry := range routeTable {
+				router.Route(entry.method, entry.path, noopHandler)
+			}
+
+			for _, entry := range routeTable {
+				r := httptest.NewRequest(entry.method, entry.url, nil)
+				rt, args := find(entry.method, entry.url)
+				legacy, legacyArgs := legacyFind(router.routes, r)
+
+				Expect(rt).To(Equal(legacy))
+				for name, value := range legacyArgs {
+					Expect(args).To(HaveKeyWithValue(name, value))
+				}
+			}
+		})
+	})
+
+	When("a static route is matched", func() {
+		It("should not allocate", func() {
+			router.Get("/defined/route", noopHandler)
+			r := httptest.NewRequest("GET", "/defined/route", nil)
+
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _, _ = router.findHandler(r)
+			})
+			Expect(allocs).To(BeZero())
+		})
+	})
+})
+
+// legacyFind is the linear matcher the router used before the radix tree, kept
+// here so the two can be compared.
+func legacyFind(routes []*route, r *http.Request) (*route, map[string]string) {
+	for _, route := range routes {
+		if !strings.EqualFold(r.Method, route.Method) {
+			continue
+		}
+
+		if match, args := legacyMatch(route.Path, r.URL.Path); match {
+			return route, args
+		}
+	}
+	return nil, nil
+}
+
+func legacyMatch(path, url string) (bool, map[string]string) {
+	urlBits := strings.Split(strings.Trim(url, "/"), "/")
+	pathBits := strings.Split(strings.Trim(path, "/"), "/")
+
+	mandatoryBits := 0
+	for _, bit := range pathBits {
+		if strings.Contains(bit, "[") && strings.Contains(bit, "]") {
+			mandatoryBits++
+		}
+	}
+
+	if len(urlBits) < mandatoryBits || len(urlBits) > len(pathBits) {
+		return false, nil
+	}
+
+	args := make(map[string]string)
+	for pos, bit := range pathBits {
+		if len(urlBits) < pos+1 {
+			if strings.Contains(bit, "[:") {
+				break
+			}
+			return false, nil
+		}
+
+		if len(bit) > 1 && bit[0:1] == ":" {
+			args[bit[1:]] = urlBits[pos]
+		} else if len(bit) > 1 && bit[0:2] == "[:" {
+			args[bit[2:len(bit)-1]] = urlBits[pos]
+		} else if urlBits[pos] != pathBits[pos] {
+			return false, nil
+		}
+	}
+	return true, args
+}
+
+func largeRouter() (*Router, []*http.Request) {
+	router := &Router{}
+	requests := make([]*http.Request, 0)
+
+	for _, entry := range routeTable {
+		router.Route(entry.method, entry.path, noopHandler)
+		requests = append(requests, httptest.NewRequest(entry.method, entry.url, nil))
+	}
+
+	for i := 0; i < 100; i++ {
+		router.Get(fmt.Sprintf("/service/%d/items", i), noopHandler)
+		router.Get(fmt.Sprintf("/service/%d/items/:id", i), noopHandler)
+		router.Post(fmt.Sprintf("/service/%d/items", i), noopHandler)
+		router.Delete(fmt.Sprintf("/service/%d/items/:id/[:version]", i), noopHandler)
+	}
+
+	requests = append(requests,
+		httptest.NewRequest("GET", "/service/99/items", nil),
+		httptest.NewRequest("GET", "/service/99/items/42", nil),
+		httptest.NewRequest("DELETE", "/service/50/items/42/3", nil),
+	)
+	return router, requests
+}
+
+func BenchmarkLinearMatcher(b *testing.B) {
+	router, requests := largeRouter()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, r := range requests {
+			legacyFind(router.routes, r)
+		}
+	}
+}
+
+func BenchmarkTreeMatcher(b *testing.B) {
+	router, requests := largeRouter()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, r := range requests {
+			_, _, _ = router.findHandler(r)
+		}
+	}
+}
+
+func BenchmarkTreeMatcherStatic(b *testing.B) {
+	router, _ := largeRouter()
+	r := httptest.NewRequest("GET", "/service/99/items", nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _, _ = router.findHandler(r)
+	}
+}
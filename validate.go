@@ -0,0 +1,420 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValidatorFunc reports whether value satisfies a validation rule. param is
+// the text after the "=" in the tag, such as "3" in "min=3".
+type ValidatorFunc func(value interface{}, param string) bool
+
+// FieldError describes a field which failed one of its validation rules.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors lists every field of a struct which failed validation.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, field := range e {
+		messages = append(messages, field.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// ErrInvalidRule is returned by ValidateStruct when a validate tag names a rule
+// which doesn't exist, or which can't be used with the field it is on.
+var ErrInvalidRule = errors.New("invalid validation rule")
+
+type validationRule struct {
+	name       string
+	param      string
+	limit      float64
+	expression *regexp.Regexp
+	validator  ValidatorFunc
+}
+
+type ruleKey struct {
+	field reflect.Type
+	tag   string
+}
+
+var validators = struct {
+	sync.RWMutex
+	custom map[string]ValidatorFunc
+	rules  map[ruleKey][]validationRule
+}{custom: make(map[string]ValidatorFunc), rules: make(map[ruleKey][]validationRule)}
+
+// RegisterValidator adds a rule which can be used in validate tags. Custom
+// rules may replace one another but not the built-in rules.
+func RegisterValidator(name string, validator ValidatorFunc) {
+	if len(name) < 1 || strings.ContainsAny(name, ",=") || builtinRule(name) {
+		panic(fmt.Sprintf("router: cannot register validator %q", name))
+	}
+
+	validators.Lock()
+	defer validators.Unlock()
+	validators.custom[name] = validator
+	validators.rules = make(map[ruleKey][]validationRule)
+}
+
+// BindAndValidate binds the request into dst and then validates it.
+func (r *request) BindAndValidate(dst interface{}) error {
+	if err := r.Bind(dst); err != nil {
+		return err
+	}
+	return ValidateStruct(dst)
+}
+
+// ValidateStruct checks the fields of a struct against their validate tags,
+// such as `validate:"required,min=3"`, returning ValidationErrors listing
+// every failure. Rules apply to zero values too, so optional fields need an
+// omitempty rule, which skips the rules after it when the field is empty. Nil
+// pointers are only checked by required. Nested structs are validated too, and
+// fields are named after their json tag if they have one. A tag which can't be
+// used is reported with an error wrapping ErrInvalidRule.
+func ValidateStruct(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("router: ValidateStruct requires a struct, got %T", v)
+	}
+
+	failures, err := validateStruct(value, "", make(ValidationErrors, 0))
+	if err != nil {
+		return err
+	}
+
+	if len(failures) < 1 {
+		return nil
+	}
+	return failures
+}
+
+func validateStruct(value reflect.Value, prefix string, failures ValidationErrors) (ValidationErrors, error) {
+	for pos := 0; pos < value.NumField(); pos++ {
+		field := value.Type().Field(pos)
+		if len(field.PkgPath) > 0 && !field.Anonymous {
+			continue
+		}
+
+		name := prefix + fieldName(field)
+		if field.Anonymous {
+			name = strings.TrimSuffix(prefix, ".")
+		}
+
+		var err error
+		if tag := field.Tag.Get("validate"); len(tag) > 0 && tag != "-" {
+			if failures, err = validateField(value.Field(pos), name, tag, failures); err != nil {
+				return failures, err
+			}
+		}
+
+		if failures, err = validateNested(value.Field(pos), name, field.Anonymous, failures); err != nil {
+			return failures, err
+		}
+	}
+	return failures, nil
+}
+
+func validateNested(value reflect.Value, name string, embedded bool, failures ValidationErrors) (ValidationErrors, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return failures, nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() == timeType {
+			return failures, nil
+		}
+
+		prefix := name + "."
+		if embedded && len(name) < 1 {
+			prefix = ""
+		}
+		return validateStruct(value, prefix, failures)
+	case reflect.Slice, reflect.Array:
+		var err error
+		for pos := 0; pos < value.Len(); pos++ {
+			if failures, err = validateNested(value.Index(pos), fmt.Sprintf("%s[%d]", name, pos), false, failures); err != nil {
+				return failures, err
+			}
+		}
+	}
+	return failures, nil
+}
+
+func validateField(value reflect.Value, name, tag string, failures ValidationErrors) (ValidationErrors, error) {
+	rules, err := fieldRules(value.Type(), tag)
+	if err != nil {
+		return failures, fmt.Errorf("router: field %q: %w", name, err)
+	}
+
+	for _, rule := range rules {
+		if rule.name == "omitempty" {
+			if isEmpty(value) {
+				return failures, nil
+			}
+			continue
+		}
+
+		passed, err := rule.check(value)
+		if err != nil {
+			return failures, fmt.Errorf("router: field %q: %w", name, err)
+		}
+
+		if !passed {
+			failures = append(failures, FieldError{
+				Field:   name,
+				Rule:    rule.name,
+				Param:   rule.param,
+				Message: ruleMessage(name, rule.name, rule.param, value),
+			})
+		}
+	}
+	return failures, nil
+}
+
+// fieldRules parses a validate tag and checks each rule against the field's
+// type. The result is cached, so each tag is only checked once per type until
+// another validator is registered.
+func fieldRules(field reflect.Type, tag string) ([]validationRule, error) {
+	key := ruleKey{field: field, tag: tag}
+
+	validators.RLock()
+	rules, cached := validators.rules[key]
+	validators.RUnlock()
+
+	if cached {
+		return rules, nil
+	}
+
+	validators.Lock()
+	defer validators.Unlock()
+
+	rules = make([]validationRule, 0)
+	for _, text := range splitRules(tag) {
+		rule, err := compileRule(field, text)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	validators.rules[key] = rules
+	return rules, nil
+}
+
+// compileRule must be called with validators locked.
+func compileRule(field reflect.Type, text string) (validationRule, error) {
+	rule := validationRule{name: text}
+	if equals := strings.Index(text, "="); equals >= 0 {
+		rule.name, rule.param = text[:equals], text[equals+1:]
+	}
+
+	for field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+
+	var err error
+	switch rule.name {
+	case "required", "omitempty", "email", "url", "oneof", "uuid":
+	case "min", "max", "len":
+		if rule.limit, err = strconv.ParseFloat(rule.param, 64); err != nil {
+			return rule, invalidRule(rule.name, "needs a number, got %q", rule.param)
+		}
+
+		if field.Kind() != reflect.Interface && !sizedKind(field.Kind()) {
+			return rule, invalidRule(rule.name, "cannot be used with %s", field)
+		}
+	case "regex":
+		if rule.expression, err = regexp.Compile("^(?:" + rule.param + ")$"); err != nil {
+			return rule, invalidRule(rule.name, "%v", err)
+		}
+	default:
+		validator, exists := validators.custom[rule.name]
+		if !exists {
+			return rule, invalidRule(rule.name, "no such rule")
+		}
+		rule.validator = validator
+	}
+	return rule, nil
+}
+
+func invalidRule(name, format string, args ...interface{}) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidRule, name, fmt.Sprintf(format, args...))
+}
+
+// splitRules separates a validate tag into rules. A regex rule takes the rest
+// of the tag so that its pattern may contain commas.
+func splitRules(tag string) []string {
+	rules := make([]string, 0)
+	for len(tag) > 0 {
+		if strings.HasPrefix(tag, "regex=") {
+			return append(rules, tag)
+		}
+
+		comma := strings.Index(tag, ",")
+		if comma < 0 {
+			return append(rules, tag)
+		}
+
+		if rule := strings.TrimSpace(tag[:comma]); len(rule) > 0 {
+			rules = append(rules, rule)
+		}
+		tag = tag[comma+1:]
+	}
+	return rules
+}
+
+func builtinRule(name string) bool {
+	switch name {
+	case "required", "omitempty", "min", "max", "len", "email", "url", "oneof", "regex", "uuid":
+		return true
+	}
+	return false
+}
+
+func (rule validationRule) check(value reflect.Value) (bool, error) {
+	if rule.name == "required" {
+		return !isEmpty(value), nil
+	}
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return true, nil
+		}
+		value = value.Elem()
+	}
+
+	text := fmt.Sprint(value.Interface())
+	switch rule.name {
+	case "min", "max", "len":
+		return rule.checkSize(value)
+	case "email":
+		address, err := mail.ParseAddress(text)
+		return err == nil && address.Address == text, nil
+	case "url":
+		parsed, err := url.Parse(text)
+		return err == nil && len(parsed.Scheme) > 0 && len(parsed.Host) > 0, nil
+	case "oneof":
+		return contains(strings.Fields(rule.param), text), nil
+	case "regex":
+		return rule.expression.MatchString(text), nil
+	case "uuid":
+		return isUUID(text), nil
+	}
+	return rule.validator(value.Interface(), rule.param), nil
+}
+
+func (rule validationRule) checkSize(value reflect.Value) (bool, error) {
+	var size float64
+	switch value.Kind() {
+	case reflect.String:
+		size = float64(utf8.RuneCountInString(value.String()))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		size = float64(value.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = value.Float()
+	default:
+		return false, invalidRule(rule.name, "cannot be used with %s", value.Type())
+	}
+
+	switch rule.name {
+	case "min":
+		return size >= rule.limit, nil
+	case "max":
+		return size <= rule.limit, nil
+	}
+	return size == rule.limit, nil
+}
+
+func sizedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map, reflect.String, reflect.Array:
+		return value.Len() < 1
+	}
+	return value.IsZero()
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "query", "path"} {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; len(name) > 0 && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func ruleMessage(name, rule, param string, value reflect.Value) string {
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	unit := ""
+	switch value.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch rule {
+	case "required":
+		return name + " is required"
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", name, param, unit)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", name, param, unit)
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s%s", name, param, unit)
+	case "email":
+		return name + " must be a valid email address"
+	case "url":
+		return name + " must be a valid URL"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", name, strings.Join(strings.Fields(param), ", "))
+	case "regex":
+		return name + " is not in the expected format"
+	case "uuid":
+		return name + " must be a valid UUID"
+	}
+	return fmt.Sprintf("%s failed the %s rule", name, rule)
+}
@@ -0,0 +1,237 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+type validateAddress struct {
+	City     string `json:"city" validate:"required"`
+	Postcode string `json:"postcode" validate:"omitempty,regex=[A-Z]{2}[0-9]{1,2}( [0-9][A-Z]{2})?"`
+}
+
+type validateSignup struct {
+	Name     string            `json:"name" validate:"required,min=2,max=10"`
+	Email    string            `json:"email" validate:"required,email"`
+	Website  string            `json:"website" validate:"omitempty,url"`
+	Plan     string            `json:"plan" validate:"omitempty,oneof=free pro"`
+	Code     string            `json:"code" validate:"omitempty,len=4"`
+	Age      *int              `json:"age" validate:"min=18"`
+	Referrer string            `json:"referrer" validate:"omitempty,uuid"`
+	Tags     []string          `json:"tags" validate:"max=2"`
+	Address  validateAddress   `json:"address"`
+	Previous []validateAddress `json:"previous"`
+	Team     string            `json:"team" validate:"omitempty,even"`
+}
+
+var _ = Describe("Validation unit tests", func() {
+
+	valid := func() validateSignup {
+		age := 30
+		return validateSignup{
+			Name:     "Ada",
+			Email:    "ada@example.org",
+			Website:  "https://example.org",
+			Plan:     "pro",
+			Code:     "AB12",
+			Age:      &age,
+			Referrer: "3b241101-e2bb-4255-8caf-4136c566a962",
+			Tags:     []string{"maths"},
+			Address:  validateAddress{City: "London", Postcode: "SW1 1AA"},
+		}
+	}
+
+	fields := func(err error) []string {
+		var failures ValidationErrors
+		Expect(errors.As(err, &failures)).To(BeTrue())
+
+		names := make([]string, 0, len(failures))
+		for _, failure := range failures {
+			names = append(names, failure.Field+":"+failure.Rule)
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		RegisterValidator("even", func(value interface{}, param string) bool {
+			return len(value.(string))%2 == 0
+		})
+	})
+
+	When("a struct satisfies its rules", func() {
+		It("should pass", func() {
+			Expect(ValidateStruct(valid())).To(Succeed())
+
+			signup := valid()
+			Expect(ValidateStruct(&signup)).To(Succeed())
+		})
+
+		It("should skip the rules after omitempty for empty values", func() {
+			signup := valid()
+			signup.Website, signup.Plan, signup.Code, signup.Referrer, signup.Address.Postcode = "", "", "", "", ""
+			Expect(ValidateStruct(signup)).To(Succeed())
+		})
+
+		It("should only check nil pointers with required", func() {
+			signup := valid()
+			signup.Age = nil
+			Expect(ValidateStruct(signup)).To(Succeed())
+		})
+	})
+
+	When("a struct breaks its rules", func() {
+		It("should list every failing field", func() {
+			age := 12
+			signup := validateSignup{
+				Name:     "A",
+				Email:    "Ada <ada@example.org>",
+				Website:  "example.org",
+				Plan:     "gold",
+				Code:     "ABC",
+				Age:      &age,
+				Referrer: "not-a-uuid",
+				Tags:     []string{"a", "b", "c"},
+				Previous: []validateAddress{{City: "Paris", Postcode: "75001"}},
+				Team:     "odd",
+			}
+
+			Expect(fields(ValidateStruct(signup))).To(Equal([]string{
+				"name:min", "email:email", "website:url", "plan:oneof", "code:len", "age:min", "referrer:uuid",
+				"tags:max", "address.city:required", "previous[0].postcode:regex", "team:even",
+			}))
+		})
+
+		It("should apply rules to zero values", func() {
+			var rules struct {
+				Age   int      `json:"age" validate:"min=18"`
+				Tags  []string `json:"tags" validate:"min=1"`
+				Plan  string   `json:"plan" validate:"oneof=free pro"`
+				Count int      `json:"count" validate:"omitempty,min=5"`
+			}
+
+			Expect(fields(ValidateStruct(rules))).To(Equal([]string{"age:min", "tags:min", "plan:oneof"}))
+		})
+
+		It("should describe each failure", func() {
+			signup := valid()
+			signup.Name = "Adelaide Augusta"
+
+			err := ValidateStruct(signup)
+			Expect(err).To(MatchError("validation failed: name must be at most 10 characters"))
+		})
+
+		It("should return an error for rules which do not exist or cannot be used", func() {
+			var unknown struct {
+				Name string `json:"name" validate:"shiny"`
+			}
+			err := ValidateStruct(unknown)
+			Expect(errors.Is(err, ErrInvalidRule)).To(BeTrue())
+			Expect(err).To(MatchError(`router: field "name": invalid validation rule "shiny": no such rule`))
+
+			var number struct {
+				Name string `validate:"min=three"`
+			}
+			Expect(errors.Is(ValidateStruct(number), ErrInvalidRule)).To(BeTrue())
+
+			var kind struct {
+				Active bool `validate:"max=1"`
+			}
+			Expect(errors.Is(ValidateStruct(kind), ErrInvalidRule)).To(BeTrue())
+
+			var pattern struct {
+				Code string `validate:"regex=[a-z"`
+			}
+			Expect(errors.Is(ValidateStruct(pattern), ErrInvalidRule)).To(BeTrue())
+		})
+
+		It("should accept a rule once it has been registered", func() {
+			var rules struct {
+				Finish string `validate:"glossy"`
+			}
+			Expect(errors.Is(ValidateStruct(rules), ErrInvalidRule)).To(BeTrue())
+
+			RegisterValidator("glossy", func(value interface{}, param string) bool {
+				return value.(string) == "gloss"
+			})
+			rules.Finish = "gloss"
+			Expect(ValidateStruct(rules)).To(Succeed())
+		})
+
+		It("should not allow the built-in rules to be replaced", func() {
+			Expect(func() { RegisterValidator("required", nil) }).To(Panic())
+		})
+	})
+
+	When("a request is bound and validated", func() {
+		serve := func(body string) *httptest.ResponseRecorder {
+			router := Router{}
+			router.Post("/signup", func(request Request) Response {
+				var signup validateSignup
+				if err := request.BindAndValidate(&signup); err != nil {
+					return request.InvalidParam(err)
+				}
+				return request.Success("welcome " + signup.Name)
+			})
+
+			r := httptest.NewRequest("POST", "/signup", strings.NewReader(body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			return w
+		}
+
+		It("should pass valid requests through", func() {
+			w := serve(`{"name": "Ada", "email": "ada@example.org", "address": {"city": "London"}}`)
+			Expect(w.Body.String()).To(Equal("welcome Ada"))
+		})
+
+		It("should respond 422 listing each failing field", func() {
+			w := serve(`{"name": "Ada", "email": "ada"}`)
+			Expect(w.Result().StatusCode).To(Equal(http.StatusUnprocessableEntity))
+
+			var body struct {
+				Error  string       `json:"error"`
+				Fields []FieldError `json:"fields"`
+			}
+			Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+			Expect(body.Error).To(Equal("validation failed"))
+			Expect(body.Fields).To(Equal([]FieldError{
+				{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+				{Field: "address.city", Rule: "required", Message: "address.city is required"},
+			}))
+		})
+
+		It("should report binding errors before validating", func() {
+			w := serve(`{"name": 12}`)
+			Expect(w.Result().StatusCode).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should respond 500 without panicking and log when a rule is invalid", func() {
+			log := &recordingLog{}
+			router := Router{}
+			router.Logger(log)
+			router.Post("/broken", func(request Request) Response {
+				var broken struct {
+					Name string `json:"name" validate:"shiny"`
+				}
+				if err := request.BindAndValidate(&broken); err != nil {
+					return request.InvalidParam(err)
+				}
+				return request.Success("accepted")
+			})
+
+			r := httptest.NewRequest("POST", "/broken", strings.NewReader(`{"name": "Ada"}`))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			Expect(w.Result().StatusCode).To(Equal(http.StatusInternalServerError))
+			Expect(w.Body.String()).ToNot(ContainSubstring("shiny"))
+			Expect(log.errors).To(HaveLen(1))
+			Expect(log.errors[0]).To(ContainSubstring(`router: field "name": invalid validation rule "shiny": no such rule`))
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package router
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Wildcard route unit tests", func() {
+
+	var router Router
+	BeforeEach(func() {
+		router = Router{}
+	})
+
+	serve := func(url string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	reply := func(text string) Handler {
+		return func(request Request) Response {
+			return request.Success(text + ":" + request.GetArg("filepath"))
+		}
+	}
+
+	When("a route ends in a wildcard segment", func() {
+		It("should capture the rest of the path, slashes included", func() {
+			router.Get("/static/*filepath", reply("static"))
+
+			Expect(serve("/static/css/site/main.css").Body.String()).To(Equal("static:css/site/main.css"))
+			Expect(serve("/static/logo.png").Body.String()).To(Equal("static:logo.png"))
+			Expect(serve("/static").Body.String()).To(Equal("static:"))
+			Expect(serve("/statics/logo.png").Result().StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("should work at the root of the router", func() {
+			router.Get("/*filepath", reply("root"))
+			Expect(serve("/any/thing").Body.String()).To(Equal("root:any/thing"))
+		})
+	})
+
+	When("a wildcard route overlaps with other routes", func() {
+		It("should lose to static and parameter routes regardless of registration order", func() {
+			router.Get("/files/*filepath", reply("wildcard"))
+			router.Get("/files/readme", reply("static"))
+			router.Get("/files/:filepath/raw", reply("param"))
+
+			Expect(serve("/files/readme").Body.String()).To(Equal("static:"))
+			Expect(serve("/files/a.txt/raw").Body.String()).To(Equal("param:a.txt"))
+			Expect(serve("/files/a/b/raw").Body.String()).To(Equal("wildcard:a/b/raw"))
+		})
+	})
+
+	When("a wildcard is not the last segment", func() {
+		It("should panic at registration", func() {
+			Expect(func() {
+				router.Get("/static/*filepath/edit", reply("broken"))
+			}).To(PanicWith(ContainSubstring("must be the last segment")))
+		})
+	})
+})